@@ -0,0 +1,266 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// The conversion has to be lossless in both directions. Values that only exist in one version are
+// kept in an annotation on the other version and restored when the object is converted back.
+//
+// The resource strings of v1alpha1 are parsed as Kubernetes quantities. Earlier operator versions cut the
+// last two characters of a memory value and read the rest as Mi, so "1Gi" was 1Mi and "256" was 2Mi. A
+// quantity keeps its unit, "1Gi" is 1Gi and a value without a unit is in bytes or cores.
+
+// V1beta1SpecAnnotation holds the v1beta1 spec when it cannot be represented in v1alpha1
+const V1beta1SpecAnnotation = "operators.ibm.com/v1beta1-spec"
+
+// V1alpha1FieldsAnnotation holds v1alpha1 values that were dropped or normalized in v1beta1
+const V1alpha1FieldsAnnotation = "operators.ibm.com/v1alpha1-fields"
+
+// alphaFields are the v1alpha1 values kept on v1beta1 objects
+type alphaFields struct {
+	// Unused legacy fields of spec.commonWebUIConfig
+	CPULimits     string `json:"cpuLimits,omitempty"`
+	CPUMemory     string `json:"cpuMemory,omitempty"`
+	RequestLimits string `json:"requestLimits,omitempty"`
+	RequestMemory string `json:"requestMemory,omitempty"`
+	// The resource quantities as written, v1beta1 stores them in canonical form and cannot hold invalid values
+	Resources *Resources `json:"resources,omitempty"`
+}
+
+var _ conversion.Convertible = &CommonWebUI{}
+
+// ConvertTo converts this CommonWebUI to the hub version (v1beta1)
+func (src *CommonWebUI) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.CommonWebUI)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	// Start from the v1beta1 spec if it was kept when converting down
+	dst.Spec = v1beta1.CommonWebUISpec{}
+	if raw, found := dst.Annotations[V1beta1SpecAnnotation]; found {
+		if err := json.Unmarshal([]byte(raw), &dst.Spec); err != nil {
+			return err
+		}
+		delete(dst.Annotations, V1beta1SpecAnnotation)
+	}
+	convertSpecTo(&src.Spec, &dst.Spec)
+
+	stash := alphaFields{
+		CPULimits:     src.Spec.CommonWebUIConfig.CPULimits,
+		CPUMemory:     src.Spec.CommonWebUIConfig.CPUMemory,
+		RequestLimits: src.Spec.CommonWebUIConfig.RequestLimits,
+		RequestMemory: src.Spec.CommonWebUIConfig.RequestMemory,
+	}
+	// The resources are only kept when they are not written in the canonical form of v1beta1
+	if src.Spec.Resources != canonicalResources(dst.Spec.Resources) {
+		stash.Resources = src.Spec.Resources.DeepCopy()
+	}
+	if stash == (alphaFields{}) {
+		delete(dst.Annotations, V1alpha1FieldsAnnotation)
+	} else {
+		raw, err := json.Marshal(stash)
+		if err != nil {
+			return err
+		}
+		setAnnotation(&dst.ObjectMeta, V1alpha1FieldsAnnotation, string(raw))
+	}
+
+	return convertStatusTo(&src.Status, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1beta1) to this version
+func (dst *CommonWebUI) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.CommonWebUI)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	stash := alphaFields{}
+	if raw, found := dst.Annotations[V1alpha1FieldsAnnotation]; found {
+		if err := json.Unmarshal([]byte(raw), &stash); err != nil {
+			return err
+		}
+		delete(dst.Annotations, V1alpha1FieldsAnnotation)
+	}
+	if stash.Resources == nil {
+		stash.Resources = &Resources{}
+	}
+
+	dst.Spec = CommonWebUISpec{
+		CommonWebUIConfig: CommonWebUIConfig{
+			ServiceName:   src.Spec.CommonWebUIConfig.ServiceName,
+			ImageRegistry: src.Spec.CommonWebUIConfig.ImageRegistry,
+			ImageTag:      src.Spec.CommonWebUIConfig.ImageTag,
+			CPULimits:     stash.CPULimits,
+			CPUMemory:     stash.CPUMemory,
+			RequestLimits: stash.RequestLimits,
+			RequestMemory: stash.RequestMemory,
+			IngressPath:   src.Spec.CommonWebUIConfig.IngressPath,
+			LandingPage:   src.Spec.CommonWebUIConfig.LandingPage,
+		},
+		GlobalUIConfig:  GlobalUIConfig(src.Spec.GlobalUIConfig),
+		OperatorVersion: src.Spec.OperatorVersion,
+		Version:         src.Spec.Version,
+		Replicas:        src.Spec.Replicas,
+		Resources: Resources{
+			Requests: Requests{
+				RequestLimits:    quantityToString(src.Spec.Resources.Requests, corev1.ResourceCPU, stash.Resources.Requests.RequestLimits),
+				RequestMemory:    quantityToString(src.Spec.Resources.Requests, corev1.ResourceMemory, stash.Resources.Requests.RequestMemory),
+				EphemeralStorage: quantityToString(src.Spec.Resources.Requests, corev1.ResourceEphemeralStorage, stash.Resources.Requests.EphemeralStorage),
+			},
+			Limits: Limits{
+				CPULimits:        quantityToString(src.Spec.Resources.Limits, corev1.ResourceCPU, stash.Resources.Limits.CPULimits),
+				CPUMemory:        quantityToString(src.Spec.Resources.Limits, corev1.ResourceMemory, stash.Resources.Limits.CPUMemory),
+				EphemeralStorage: quantityToString(src.Spec.Resources.Limits, corev1.ResourceEphemeralStorage, stash.Resources.Limits.EphemeralStorage),
+			},
+		},
+		Labels:                        src.Spec.Labels,
+		EnableInstanaMetricCollection: src.Spec.EnableInstanaMetricCollection,
+		LoginConfirmation:             LoginConfirmation(src.Spec.LoginConfirmation),
+		AutoScaleConfig:               src.Spec.GetAutoScaling().IsEnabled(),
+	}
+
+	// Keep the v1beta1 spec when converting it back would not give the same result
+	roundTrip := v1beta1.CommonWebUISpec{}
+	convertSpecTo(&dst.Spec, &roundTrip)
+	if equality.Semantic.DeepEqual(roundTrip, src.Spec) {
+		delete(dst.Annotations, V1beta1SpecAnnotation)
+	} else {
+		raw, err := json.Marshal(src.Spec)
+		if err != nil {
+			return err
+		}
+		setAnnotation(&dst.ObjectMeta, V1beta1SpecAnnotation, string(raw))
+	}
+
+	return convertStatusFrom(&src.Status, &dst.Status)
+}
+
+// convertSpecTo sets the v1beta1 fields that are known to v1alpha1, other fields of dst are left as is
+func convertSpecTo(src *CommonWebUISpec, dst *v1beta1.CommonWebUISpec) {
+	dst.CommonWebUIConfig.ServiceName = src.CommonWebUIConfig.ServiceName
+	dst.CommonWebUIConfig.ImageRegistry = src.CommonWebUIConfig.ImageRegistry
+	dst.CommonWebUIConfig.ImageTag = src.CommonWebUIConfig.ImageTag
+	dst.CommonWebUIConfig.IngressPath = src.CommonWebUIConfig.IngressPath
+	dst.CommonWebUIConfig.LandingPage = src.CommonWebUIConfig.LandingPage
+	dst.GlobalUIConfig = v1beta1.GlobalUIConfig(src.GlobalUIConfig)
+	dst.OperatorVersion = src.OperatorVersion
+	dst.Version = src.Version
+	dst.Replicas = src.Replicas
+	dst.Labels = src.Labels
+	dst.EnableInstanaMetricCollection = src.EnableInstanaMetricCollection
+	dst.LoginConfirmation = v1beta1.LoginConfirmation(src.LoginConfirmation)
+	// The autoscaler settings of v1beta1 are kept, only turned on or off with the boolean of v1alpha1
+	if dst.AutoScaling == nil {
+		dst.AutoScaleConfig = src.AutoScaleConfig
+	} else if dst.AutoScaling.IsEnabled() != src.AutoScaleConfig {
+		enabled := src.AutoScaleConfig
+		dst.AutoScaling.Enabled = &enabled
+	}
+
+	dst.Resources.Requests = setQuantity(dst.Resources.Requests, corev1.ResourceCPU, src.Resources.Requests.RequestLimits)
+	dst.Resources.Requests = setQuantity(dst.Resources.Requests, corev1.ResourceMemory, src.Resources.Requests.RequestMemory)
+	dst.Resources.Requests = setQuantity(dst.Resources.Requests, corev1.ResourceEphemeralStorage, src.Resources.Requests.EphemeralStorage)
+	dst.Resources.Limits = setQuantity(dst.Resources.Limits, corev1.ResourceCPU, src.Resources.Limits.CPULimits)
+	dst.Resources.Limits = setQuantity(dst.Resources.Limits, corev1.ResourceMemory, src.Resources.Limits.CPUMemory)
+	dst.Resources.Limits = setQuantity(dst.Resources.Limits, corev1.ResourceEphemeralStorage, src.Resources.Limits.EphemeralStorage)
+}
+
+// canonicalResources returns the v1alpha1 resources as they are written when converted from v1beta1
+func canonicalResources(resources corev1.ResourceRequirements) Resources {
+	return Resources{
+		Requests: Requests{
+			RequestLimits:    quantityToString(resources.Requests, corev1.ResourceCPU, ""),
+			RequestMemory:    quantityToString(resources.Requests, corev1.ResourceMemory, ""),
+			EphemeralStorage: quantityToString(resources.Requests, corev1.ResourceEphemeralStorage, ""),
+		},
+		Limits: Limits{
+			CPULimits:        quantityToString(resources.Limits, corev1.ResourceCPU, ""),
+			CPUMemory:        quantityToString(resources.Limits, corev1.ResourceMemory, ""),
+			EphemeralStorage: quantityToString(resources.Limits, corev1.ResourceEphemeralStorage, ""),
+		},
+	}
+}
+
+// setQuantity sets or removes a quantity in the list, values that do not parse are removed
+func setQuantity(list corev1.ResourceList, name corev1.ResourceName, value string) corev1.ResourceList {
+	quantity, err := resource.ParseQuantity(value)
+	if value == "" || err != nil {
+		delete(list, name)
+		if len(list) == 0 {
+			return nil
+		}
+		return list
+	}
+	if list == nil {
+		list = corev1.ResourceList{}
+	}
+	list[name] = quantity
+	return list
+}
+
+// quantityToString returns the value as originally written in v1alpha1 if it still matches the v1beta1 quantity
+func quantityToString(list corev1.ResourceList, name corev1.ResourceName, original string) string {
+	quantity, found := list[name]
+	originalQuantity, err := resource.ParseQuantity(original)
+	if !found {
+		// An original value that never parsed has no v1beta1 counterpart and is kept as is
+		if original != "" && err != nil {
+			return original
+		}
+		return ""
+	}
+	if err == nil && originalQuantity.Cmp(quantity) == 0 {
+		return original
+	}
+	return quantity.String()
+}
+
+func setAnnotation(objectMeta *metav1.ObjectMeta, key, value string) {
+	if objectMeta.Annotations == nil {
+		objectMeta.Annotations = map[string]string{}
+	}
+	objectMeta.Annotations[key] = value
+}
+
+func convertStatusTo(src *CommonWebUIStatus, dst *v1beta1.CommonWebUIStatus) error {
+	raw, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	*dst = v1beta1.CommonWebUIStatus{}
+	return json.Unmarshal(raw, dst)
+}
+
+func convertStatusFrom(src *v1beta1.CommonWebUIStatus, dst *CommonWebUIStatus) error {
+	raw, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	*dst = CommonWebUIStatus{}
+	return json.Unmarshal(raw, dst)
+}
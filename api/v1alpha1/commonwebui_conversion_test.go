@@ -0,0 +1,196 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+func TestConvertV1alpha1RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		spec CommonWebUISpec
+	}{
+		{
+			name: "empty spec",
+		},
+		{
+			name: "all fields",
+			spec: CommonWebUISpec{
+				CommonWebUIConfig: CommonWebUIConfig{
+					ServiceName:   "common-web-ui",
+					ImageRegistry: "icr.io/cpopen/cpfs",
+					ImageTag:      "4.13.0",
+					IngressPath:   "/common-nav",
+					LandingPage:   "/common-nav/dashboard",
+				},
+				GlobalUIConfig: GlobalUIConfig{
+					CloudPakVersion:        "3.6.0",
+					DefaultAdminUser:       "admin",
+					SessionPollingInterval: 5000,
+				},
+				OperatorVersion:               "4.13.0",
+				Version:                       "4.13.0",
+				Replicas:                      2,
+				Labels:                        map[string]string{"team": "console"},
+				EnableInstanaMetricCollection: true,
+				LoginConfirmation:             LoginConfirmation{Text: "text", ButtonText: "OK", TitleText: "title"},
+				AutoScaleConfig:               true,
+			},
+		},
+		{
+			name: "canonical resources",
+			spec: CommonWebUISpec{Resources: Resources{
+				Requests: Requests{RequestLimits: "300m", RequestMemory: "256Mi", EphemeralStorage: "251Mi"},
+				Limits:   Limits{CPULimits: "1", CPUMemory: "512Mi", EphemeralStorage: "1Gi"},
+			}},
+		},
+		{
+			name: "resources that are not in canonical form",
+			spec: CommonWebUISpec{Resources: Resources{
+				Requests: Requests{RequestLimits: "0.3", RequestMemory: "1024Mi"},
+				Limits:   Limits{CPULimits: "1000m", CPUMemory: "1G"},
+			}},
+		},
+		{
+			name: "resources that do not parse",
+			spec: CommonWebUISpec{Resources: Resources{
+				Requests: Requests{RequestLimits: "300 millicores"},
+				Limits:   Limits{CPUMemory: "256MB"},
+			}},
+		},
+		{
+			name: "legacy commonWebUIConfig resources",
+			spec: CommonWebUISpec{CommonWebUIConfig: CommonWebUIConfig{
+				CPULimits:     "300",
+				CPUMemory:     "256",
+				RequestLimits: "300",
+				RequestMemory: "256",
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := &CommonWebUI{ObjectMeta: metav1.ObjectMeta{Name: "example-commonwebui"}, Spec: test.spec}
+
+			hub := &v1beta1.CommonWebUI{}
+			if err := src.DeepCopy().ConvertTo(hub); err != nil {
+				t.Fatalf("converting to v1beta1: %v", err)
+			}
+			dst := &CommonWebUI{}
+			if err := dst.ConvertFrom(hub); err != nil {
+				t.Fatalf("converting from v1beta1: %v", err)
+			}
+
+			if !equality.Semantic.DeepEqual(src.Spec, dst.Spec) {
+				t.Errorf("spec changed in the round trip\nbefore: %+v\nafter:  %+v", src.Spec, dst.Spec)
+			}
+			if len(dst.Annotations) != 0 {
+				t.Errorf("conversion annotations were left on the object: %v", dst.Annotations)
+			}
+		})
+	}
+}
+
+func TestConvertV1beta1RoundTrip(t *testing.T) {
+	enabled := true
+	minReplicas := int32(2)
+
+	tests := []struct {
+		name string
+		spec v1beta1.CommonWebUISpec
+	}{
+		{
+			name: "empty spec",
+		},
+		{
+			name: "resources",
+			spec: v1beta1.CommonWebUISpec{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("300m"), corev1.ResourceMemory: resource.MustParse("1G")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			}},
+		},
+		{
+			name: "resources that v1alpha1 cannot hold",
+			spec: v1beta1.CommonWebUISpec{Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+			}},
+		},
+		{
+			name: "autoscaler settings",
+			spec: v1beta1.CommonWebUISpec{AutoScaling: &v1beta1.AutoScaleConfig{Enabled: &enabled, MinReplicas: &minReplicas}},
+		},
+		{
+			name: "deprecated autoscaler boolean",
+			spec: v1beta1.CommonWebUISpec{AutoScaleConfig: true},
+		},
+		{
+			name: "v1beta1 only fields",
+			spec: v1beta1.CommonWebUISpec{
+				CommonWebUIConfig: v1beta1.CommonWebUIConfig{Env: []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}},
+				Route:             &v1beta1.RouteConfig{Host: "console.example.com"},
+				Certificate:       &v1beta1.CertificateConfig{SecretName: "console-cert"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := &v1beta1.CommonWebUI{ObjectMeta: metav1.ObjectMeta{Name: "example-commonwebui"}, Spec: test.spec}
+
+			spoke := &CommonWebUI{}
+			if err := spoke.ConvertFrom(src.DeepCopy()); err != nil {
+				t.Fatalf("converting to v1alpha1: %v", err)
+			}
+			dst := &v1beta1.CommonWebUI{}
+			if err := spoke.ConvertTo(dst); err != nil {
+				t.Fatalf("converting from v1alpha1: %v", err)
+			}
+
+			if !equality.Semantic.DeepEqual(src.Spec, dst.Spec) {
+				t.Errorf("spec changed in the round trip\nbefore: %+v\nafter:  %+v", src.Spec, dst.Spec)
+			}
+			if len(dst.Annotations) != 0 {
+				t.Errorf("conversion annotations were left on the object: %v", dst.Annotations)
+			}
+		})
+	}
+}
+
+func TestConvertV1alpha1Memory(t *testing.T) {
+	// v1alpha1 memory values are quantities, a value without a unit is in bytes and not in Mi
+	src := &CommonWebUI{Spec: CommonWebUISpec{Resources: Resources{
+		Limits: Limits{CPUMemory: "1G"},
+	}}}
+
+	hub := &v1beta1.CommonWebUI{}
+	if err := src.ConvertTo(hub); err != nil {
+		t.Fatalf("converting to v1beta1: %v", err)
+	}
+	limit := hub.Spec.Resources.Limits[corev1.ResourceMemory]
+	if limit.Value() != 1000*1000*1000 {
+		t.Errorf("expected a memory limit of 1G, got %s", limit.String())
+	}
+}
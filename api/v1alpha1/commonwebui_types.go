@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,14 +38,15 @@ type CommonWebUIConfig struct {
 }
 
 type GlobalUIConfig struct {
-	PullSecret             string `json:"pullSecret,omitempty"`
-	CloudPakVersion        string `json:"cloudPakVersion,omitempty"`
-	DefaultAdminUser       string `json:"defaultAdminUser,omitempty"`
-	DefaultAuth            string `json:"defaultAuth,omitempty"`
-	OSAuth                 string `json:"osAuth,omitempty"`
-	EnterpriseLDAP         string `json:"enterpriseLDAP,omitempty"`
-	EnterpriseSAML         string `json:"enterpriseSAML,omitempty"`
-	SessionPollingInterval int32  `json:"sessionPollingInterval,omitempty"`
+	PullSecret       string `json:"pullSecret,omitempty"`
+	CloudPakVersion  string `json:"cloudPakVersion,omitempty"`
+	DefaultAdminUser string `json:"defaultAdminUser,omitempty"`
+	DefaultAuth      string `json:"defaultAuth,omitempty"`
+	OSAuth           string `json:"osAuth,omitempty"`
+	EnterpriseLDAP   string `json:"enterpriseLDAP,omitempty"`
+	EnterpriseSAML   string `json:"enterpriseSAML,omitempty"`
+	// SessionPollingInterval is the session polling interval of the console in milliseconds
+	SessionPollingInterval int32 `json:"sessionPollingInterval,omitempty"`
 }
 
 type Resources struct {
@@ -97,6 +100,53 @@ type CommonWebUIStatus struct {
 	Service         ServiceStatus `json:"service,omitempty"`
 	OperatorVersion string        `json:"operatorVersion,omitempty"`
 	OperandVersion  string        `json:"operandVersion,omitempty"`
+	// ObservedGeneration is the most recent generation of the CR that the operator has reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the current state of the CommonWebUI service
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// EffectiveSpec shows the configuration the operator applies, including the defaults for unset fields
+	EffectiveSpec *EffectiveSpec `json:"effectiveSpec,omitempty"`
+	// LastDrift lists the fields of the managed resources that were changed outside of the operator
+	// and reverted by the last reconcile that found a difference
+	LastDrift *DriftReport `json:"lastDrift,omitempty"`
+}
+
+// DriftReport records when the operator reverted changes made to its managed resources
+type DriftReport struct {
+	// Time is when the changes were reverted
+	Time metav1.Time `json:"time"`
+	// Resources are the managed resources that were reverted
+	Resources []ResourceDrift `json:"resources,omitempty"`
+}
+
+// ResourceDrift lists the reverted fields of a managed resource
+type ResourceDrift struct {
+	Kind       string `json:"kind"`
+	ObjectName string `json:"objectName"`
+	// Fields are the reverted fields in the form `path: current -> desired`
+	Fields []string `json:"fields,omitempty"`
+}
+
+// EffectiveSpec holds the values used for the common-web-ui deployment after defaulting
+type EffectiveSpec struct {
+	Replicas      int32  `json:"replicas"`
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+	ImageTag      string `json:"imageTag,omitempty"`
+	// Image is the image that is deployed, it is taken from RELATED_IMAGE_COMMON_WEB_UI_IMAGE when that is set
+	Image     string                      `json:"image,omitempty"`
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// AutoScaling shows the HorizontalPodAutoscaler settings, it is not set when autoscaling is off
+	AutoScaling *EffectiveAutoScaling `json:"autoScaling,omitempty"`
+}
+
+// EffectiveAutoScaling holds the settings of the HorizontalPodAutoscaler after defaulting
+type EffectiveAutoScaling struct {
+	MinReplicas int32                                          `json:"minReplicas"`
+	MaxReplicas int32                                          `json:"maxReplicas"`
+	Metrics     []autoscalingv2.MetricSpec                     `json:"metrics,omitempty"`
+	Behavior    *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
 }
 
 // ServiceStatus struct
@@ -105,7 +155,6 @@ type ServiceStatus struct {
 	APIVersion       string                  `json:"apiVersion,omitempty"`
 	Namespace        string                  `json:"namespace,omitempty"`
 	Kind             string                  `json:"kind,omitempty"`
-	Status           string                  `json:"status,omitempty"`
 	ManagedResources []ManagedResourceStatus `json:"managedResources,omitempty"`
 }
 
@@ -115,6 +164,8 @@ type ManagedResourceStatus struct {
 	Namespace  string `json:"namespace,omitempty"`
 	Kind       string `json:"kind,omitempty"`
 	Status     string `json:"status,omitempty"`
+	// Unmanaged is true when the object has the unmanaged annotation and is not changed by the operator
+	Unmanaged bool `json:"unmanaged,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,143 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+var commonwebuilog = logf.Log.WithName("commonwebui-resource")
+
+func (r *CommonWebUI) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-operators-ibm-com-v1alpha1-commonwebui,mutating=false,failurePolicy=fail,sideEffects=None,groups=operators.ibm.com,resources=commonwebuis,verbs=create;update,versions=v1alpha1,name=vcommonwebui.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &CommonWebUI{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *CommonWebUI) ValidateCreate() error {
+	commonwebuilog.Info("validate create", "name", r.Name, "namespace", r.Namespace)
+	return r.validateCommonWebUI()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *CommonWebUI) ValidateUpdate(old runtime.Object) error {
+	commonwebuilog.Info("validate update", "name", r.Name, "namespace", r.Namespace)
+	return r.validateCommonWebUI()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *CommonWebUI) ValidateDelete() error {
+	return nil
+}
+
+func (r *CommonWebUI) validateCommonWebUI() error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if r.Spec.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("replicas"), r.Spec.Replicas, "must be greater than or equal to 0"))
+	}
+
+	allErrs = append(allErrs, validateResources(r.Spec.Resources, specPath.Child("resources"))...)
+
+	interval := r.Spec.GlobalUIConfig.SessionPollingInterval
+	if interval != 0 && (interval < v1beta1.MinSessionPollingInterval || interval > v1beta1.MaxSessionPollingInterval) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("globalUIConfig", "sessionPollingInterval"), interval,
+			fmt.Sprintf("must be between %d and %d milliseconds", v1beta1.MinSessionPollingInterval, v1beta1.MaxSessionPollingInterval)))
+	}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabels(r.Spec.Labels, specPath.Child("labels"))...)
+
+	if r.Spec.CommonWebUIConfig.LandingPage != "" {
+		allErrs = append(allErrs, v1beta1.ValidateLandingPage(r.Spec.CommonWebUIConfig.LandingPage, specPath.Child("commonWebUIConfig", "landingPage"))...)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("CommonWebUI").GroupKind(), r.Name, allErrs)
+}
+
+func validateResources(resources Resources, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	defaultLimits := v1beta1.DefaultResources().Limits
+
+	requestsPath := fldPath.Child("requests")
+	limitsPath := fldPath.Child("limits")
+
+	type quantityPair struct {
+		name    string
+		request string
+		limit   string
+	}
+	pairs := []quantityPair{
+		{name: "cpu", request: resources.Requests.RequestLimits, limit: resources.Limits.CPULimits},
+		{name: "memory", request: resources.Requests.RequestMemory, limit: resources.Limits.CPUMemory},
+		{name: "ephemeral-storage", request: resources.Requests.EphemeralStorage, limit: resources.Limits.EphemeralStorage},
+	}
+
+	for _, pair := range pairs {
+		request, requestErrs := parseQuantity(pair.request, requestsPath.Child(pair.name))
+		limit, limitErrs := parseQuantity(pair.limit, limitsPath.Child(pair.name))
+		allErrs = append(allErrs, requestErrs...)
+		allErrs = append(allErrs, limitErrs...)
+
+		//Requests are compared to the limit the container gets, which is the default when the CR does not set one
+		if limit == nil && pair.limit == "" {
+			if defaultLimit, found := defaultLimits[corev1.ResourceName(pair.name)]; found {
+				limit = &defaultLimit
+			}
+		}
+		if request != nil && limit != nil && request.Cmp(*limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(requestsPath.Child(pair.name), pair.request,
+				fmt.Sprintf("must be less than or equal to %s limit of %s", pair.name, limit.String())))
+		}
+	}
+
+	return allErrs
+}
+
+func parseQuantity(value string, fldPath *field.Path) (*resource.Quantity, field.ErrorList) {
+	if value == "" {
+		return nil, nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil, field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	if quantity.Sign() < 0 {
+		return nil, field.ErrorList{field.Invalid(fldPath, value, "must be greater than or equal to 0")}
+	}
+	return &quantity, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCommonWebUI(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(spec *CommonWebUISpec)
+		// errors lists the field paths that are expected to be rejected, an empty list means the CR is valid
+		errors []string
+	}{
+		{
+			name:   "empty spec",
+			mutate: func(spec *CommonWebUISpec) {},
+		},
+		{
+			name: "request within the limit",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.Resources.Requests.RequestLimits = "2"
+				spec.Resources.Limits.CPULimits = "2000m"
+			},
+		},
+		{
+			name: "request above the limit",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.Resources.Requests.RequestMemory = "300Mi"
+				spec.Resources.Limits.CPUMemory = "256Mi"
+			},
+			errors: []string{"spec.resources.requests.memory"},
+		},
+		{
+			name:   "cpu request above the default limit",
+			mutate: func(spec *CommonWebUISpec) { spec.Resources.Requests.RequestLimits = "2" },
+			errors: []string{"spec.resources.requests.cpu"},
+		},
+		{
+			name:   "memory request above the default limit",
+			mutate: func(spec *CommonWebUISpec) { spec.Resources.Requests.RequestMemory = "1Gi" },
+			errors: []string{"spec.resources.requests.memory"},
+		},
+		{
+			name:   "unparseable quantity",
+			mutate: func(spec *CommonWebUISpec) { spec.Resources.Limits.CPULimits = "one" },
+			errors: []string{"spec.resources.limits.cpu"},
+		},
+		{
+			name:   "session polling interval of the samples",
+			mutate: func(spec *CommonWebUISpec) { spec.GlobalUIConfig.SessionPollingInterval = 5000 },
+		},
+		{
+			name:   "session polling interval in seconds",
+			mutate: func(spec *CommonWebUISpec) { spec.GlobalUIConfig.SessionPollingInterval = 300 },
+			errors: []string{"spec.globalUIConfig.sessionPollingInterval"},
+		},
+		{
+			name:   "invalid label key",
+			mutate: func(spec *CommonWebUISpec) { spec.Labels = map[string]string{"-invalid": "value"} },
+			errors: []string{"spec.labels"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := &CommonWebUI{}
+			instance.Name = "example-commonwebui"
+			test.mutate(&instance.Spec)
+
+			err := instance.validateCommonWebUI()
+			if len(test.errors) == 0 {
+				if err != nil {
+					t.Fatalf("expected the CR to be valid, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors for %v, got none", test.errors)
+			}
+			for _, path := range test.errors {
+				if !strings.Contains(err.Error(), path) {
+					t.Errorf("expected an error for %s, got %v", path, err)
+				}
+			}
+		})
+	}
+}
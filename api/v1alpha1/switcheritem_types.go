@@ -40,8 +40,24 @@ type SwitcherItemSpec struct {
 // SwitcherItemStatus defines the observed state of SwitcherItem
 type SwitcherItemStatus struct {
 	// Versions Versions `json:"versions,omitempty"`
+	// ObservedGeneration is the most recent generation of the SwitcherItem that the operator has reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions report whether the item is valid and published in the product switcher
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// Condition types reported in the SwitcherItem status
+const SwitcherItemConditionValid string = "Valid"
+const SwitcherItemConditionPublished string = "Published"
+
+// Condition reasons reported in the SwitcherItem status
+const ReasonValidationSucceeded string = "ValidationSucceeded"
+const ReasonInvalidCloudPakInfo string = "InvalidCloudPakInfo"
+const ReasonPublished string = "Published"
+const ReasonDuplicateLabel string = "DuplicateLabel"
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
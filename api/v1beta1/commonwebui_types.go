@@ -0,0 +1,419 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// CommonWebUIConfig defines the common-web-ui service settings
+type CommonWebUIConfig struct {
+	ServiceName   string `json:"serviceName,omitempty"`
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+	ImageTag      string `json:"imageTag,omitempty"`
+	IngressPath   string `json:"ingressPath,omitempty"`
+	LandingPage   string `json:"landingPage,omitempty"`
+	// Env holds additional environment variables for the common-web-ui container. A variable with the
+	// same name as one set by the operator replaces it.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// GlobalUIConfig defines the cluster settings consumed by common-web-ui
+type GlobalUIConfig struct {
+	PullSecret       string `json:"pullSecret,omitempty"`
+	CloudPakVersion  string `json:"cloudPakVersion,omitempty"`
+	DefaultAdminUser string `json:"defaultAdminUser,omitempty"`
+	DefaultAuth      string `json:"defaultAuth,omitempty"`
+	OSAuth           string `json:"osAuth,omitempty"`
+	EnterpriseLDAP   string `json:"enterpriseLDAP,omitempty"`
+	EnterpriseSAML   string `json:"enterpriseSAML,omitempty"`
+	// SessionPollingInterval is the session polling interval of the console in milliseconds
+	SessionPollingInterval int32 `json:"sessionPollingInterval,omitempty"`
+}
+
+// LoginConfirmation defines the attributes used for a login confirmation dialog
+type LoginConfirmation struct {
+	Text       string `json:"text,omitempty"`
+	ButtonText string `json:"buttonText,omitempty"`
+	TitleText  string `json:"titleText,omitempty"`
+}
+
+// CommonWebUISpec defines the desired state of CommonWebUI
+type CommonWebUISpec struct {
+	CommonWebUIConfig CommonWebUIConfig `json:"commonWebUIConfig,omitempty"`
+	GlobalUIConfig    GlobalUIConfig    `json:"globalUIConfig,omitempty"`
+	OperatorVersion   string            `json:"operatorVersion,omitempty"`
+	Version           string            `json:"version,omitempty"`
+	Replicas          int32             `json:"replicas,omitempty"`
+	// Resources are the compute resources of the common-web-ui container. Only cpu, memory and
+	// ephemeral-storage are applied, unset values fall back to the operator defaults.
+	Resources                     corev1.ResourceRequirements `json:"resources,omitempty"`
+	Labels                        map[string]string           `json:"labels,omitempty"`
+	EnableInstanaMetricCollection bool                        `json:"enableInstanaMetricCollection,omitempty"`
+	LoginConfirmation             LoginConfirmation           `json:"loginConfirmation,omitempty"`
+	// AutoScaleConfig enables the HorizontalPodAutoscaler with the default settings.
+	// Deprecated: use autoScaling, which takes precedence when both are set.
+	AutoScaleConfig bool `json:"autoScaleConfig,omitempty"`
+	// AutoScaling configures the HorizontalPodAutoscaler of the common-web-ui deployment
+	AutoScaling *AutoScaleConfig `json:"autoScaling,omitempty"`
+	// Scheduling overrides where the common-web-ui pods are placed
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+	// PodDisruptionBudget overrides the disruption budget of the common-web-ui pods
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+	// NetworkPolicy configures the NetworkPolicy that restricts the traffic to the common-web-ui pods
+	NetworkPolicy *NetworkPolicyConfig `json:"networkPolicy,omitempty"`
+	// Ingress configures the Ingress that exposes the console on CNCF clusters
+	Ingress *IngressConfig `json:"ingress,omitempty"`
+	// Exposure selects how the console is exposed outside of the cluster
+	Exposure *ExposureConfig `json:"exposure,omitempty"`
+	// Route customizes the cp-console route on OpenShift
+	Route *RouteConfig `json:"route,omitempty"`
+	// Certificate customizes the common-web-ui certificate
+	Certificate *CertificateConfig `json:"certificate,omitempty"`
+}
+
+// CertificateConfig customizes the cert-manager certificate of the common-web-ui pods, which is issued into
+// the common-web-ui-cert secret. The certificate is issued by the cs-ca-issuer Issuer for 398 days and
+// renewed 120 days before it expires when nothing is set.
+type CertificateConfig struct {
+	// SecretName is an existing TLS secret in the namespace of the CR with tls.crt, tls.key and ca.crt. When
+	// it is set, the secret is mounted into the pods instead of common-web-ui-cert and no cert-manager
+	// certificate is created, the other certificate settings are not used. The certificate must chain to the
+	// ca.crt and be valid for the DNS names of the common-web-ui service.
+	SecretName string `json:"secretName,omitempty"`
+	// IssuerName is the issuer of the certificate, cs-ca-issuer by default
+	IssuerName string `json:"issuerName,omitempty"`
+	// IssuerKind is Issuer or ClusterIssuer, Issuer by default
+	//+kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	IssuerKind string `json:"issuerKind,omitempty"`
+	// Duration is the requested lifetime of the certificate
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// RenewBefore is how long before its expiry the certificate is renewed
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// PrivateKeyAlgorithm is RSA, ECDSA or Ed25519, the cert-manager default is used when it is not set
+	//+kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+	PrivateKeyAlgorithm string `json:"privateKeyAlgorithm,omitempty"`
+	// PrivateKeySize is the key size in bits for RSA or the curve size for ECDSA
+	PrivateKeySize int `json:"privateKeySize,omitempty"`
+	// RotationPolicy is Never or Always, with Always a new private key is generated on every renewal
+	//+kubebuilder:validation:Enum=Never;Always
+	RotationPolicy string `json:"rotationPolicy,omitempty"`
+	// DNSNames are added to the DNS names of the common-web-ui service
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// IPAddresses are the IP SANs of the certificate
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+}
+
+// TLS terminations of the cp-console route
+const RouteTerminationReencrypt string = "reencrypt"
+const RouteTerminationPassthrough string = "passthrough"
+
+// RouteConfig customizes the cp-console route. The route is recreated when the host or the wildcard
+// policy changes, because they cannot be updated.
+type RouteConfig struct {
+	// Host of the route, the cluster_address of the ibmcloud-cluster-info configmap is used when it is not set
+	Host string `json:"host,omitempty"`
+	// Termination is reencrypt or passthrough, reencrypt by default. With reencrypt the router verifies
+	// the common-web-ui certificate with the CA of the common-web-ui-cert secret. With passthrough the
+	// common-web-ui certificate is presented to the clients. Edge is not supported because the pods only
+	// serve HTTPS.
+	//+kubebuilder:validation:Enum=reencrypt;passthrough
+	Termination string `json:"termination,omitempty"`
+	// TLSSecretName is a secret in the namespace of the CR with the serving certificate of the host in
+	// tls.crt and tls.key, and optionally the CA chain in ca.crt. The default certificate of the router is
+	// used when it is not set. It is not used with passthrough.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// Annotations are added to the route, they replace the default HAProxy annotations with the same key
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// WildcardPolicy is None or Subdomain, None by default
+	//+kubebuilder:validation:Enum=None;Subdomain
+	WildcardPolicy string `json:"wildcardPolicy,omitempty"`
+}
+
+// Modes of exposing the console outside of the cluster
+const ExposureModeRoute string = "Route"
+const ExposureModeIngress string = "Ingress"
+const ExposureModeGateway string = "Gateway"
+const ExposureModeNone string = "None"
+
+// ExposureConfig selects how the console is exposed outside of the cluster
+type ExposureConfig struct {
+	// Mode is Route, Ingress, Gateway or None. When it is not set, the cp-console route is used on OpenShift.
+	// On CNCF clusters the Ingress is used when it is enabled, otherwise the Gateway API is used when its
+	// CRDs are installed and a gateway is configured.
+	//+kubebuilder:validation:Enum=Route;Ingress;Gateway;None
+	Mode string `json:"mode,omitempty"`
+	// Gateway configures the cp-console HTTPRoute of the Gateway mode
+	Gateway *GatewayExposure `json:"gateway,omitempty"`
+}
+
+// GatewayExposure defines the cp-console HTTPRoute. The route host is the cluster_address of the
+// ibmcloud-cluster-info configmap, a BackendTLSPolicy makes the gateway verify the common-web-ui
+// certificate with the CA of the common-web-ui-cert secret.
+type GatewayExposure struct {
+	// ParentRef is the Gateway the HTTPRoute attaches to
+	ParentRef GatewayParentRef `json:"parentRef"`
+}
+
+// GatewayParentRef references a Gateway listener
+type GatewayParentRef struct {
+	// Name of the Gateway
+	Name string `json:"name"`
+	// Namespace of the Gateway, the namespace of the CR when it is not set
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the Gateway listener, all listeners are used when it is not set
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// IngressConfig defines the cp-console Ingress on CNCF clusters. The host is the cluster_address of the
+// ibmcloud-cluster-info configmap and the ingress controller connects to the common-web-ui service over TLS.
+type IngressConfig struct {
+	// Enabled turns the managed Ingress on, it is off by default. It is not used on OpenShift, where the
+	// console is exposed by the cp-console route, unless spec.exposure.mode is Ingress.
+	Enabled bool `json:"enabled,omitempty"`
+	// IngressClassName is the class of the ingress controller, the default class of the cluster is used
+	// when it is not set
+	IngressClassName string `json:"ingressClassName,omitempty"`
+	// TLSSecretName is the secret with the TLS certificate of the host, the default certificate of the
+	// ingress controller is used when it is not set
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// Annotations are added to the Ingress, they replace the default annotations with the same key
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// NetworkPolicyConfig defines the NetworkPolicy of the common-web-ui pods. When enabled, ingress to
+// port 3000 is only allowed from the router or ingress controller namespace, the namespace of the CR,
+// the namespaces in WATCH_NAMESPACE and the additional peers.
+type NetworkPolicyConfig struct {
+	// Enabled turns the managed NetworkPolicy on, it is off by default
+	Enabled bool `json:"enabled,omitempty"`
+	// IngressControllerNamespace is the namespace of the ingress controller on CNCF clusters,
+	// ingress-nginx by default. On OpenShift the router namespaces are selected by their policy group.
+	IngressControllerNamespace string `json:"ingressControllerNamespace,omitempty"`
+	// GatewayNamespace is the namespace of the gateway data plane pods with the Gateway exposure mode, the
+	// namespace of the Gateway by default
+	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+	// AdditionalPeers are allowed to connect in addition to the defaults
+	AdditionalPeers []networkingv1.NetworkPolicyPeer `json:"additionalPeers,omitempty"`
+}
+
+// PodDisruptionBudgetConfig defines the PodDisruptionBudget of the common-web-ui pods. By default a
+// budget is created when at least two replicas run, or the HPA minimum is at least two, and allows half
+// of those replicas to be unavailable. No budget is created for a single replica because it would block
+// node drains, the PodDisruptionBudgetSkipped condition reports this when the budget is enabled.
+type PodDisruptionBudgetConfig struct {
+	// Enabled turns the disruption budget on or off, it is on by default
+	Enabled *bool `json:"enabled,omitempty"`
+	// MinAvailable is the number or percentage of pods that must stay available, it cannot be
+	// combined with maxUnavailable
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or percentage of pods that can be unavailable
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// AutoScaleConfig defines the HorizontalPodAutoscaler of the common-web-ui deployment. Unset fields
+// are derived from the replicas and the memory resources as before.
+type AutoScaleConfig struct {
+	// Enabled turns the autoscaler on or off, it is on when the object is given without this field
+	Enabled *bool `json:"enabled,omitempty"`
+	// MinReplicas is the lower limit for the number of replicas
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of replicas
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// TargetCPUUtilizationPercentage is the target average CPU utilization of the requested CPU
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// TargetMemoryUtilizationPercentage is the target average memory utilization of the requested memory
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+	// Metrics are additional metrics, such as pods, object or external metrics, used to compute the replicas
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+	// Behavior configures the scale up and scale down policies and stabilization windows
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// IsEnabled returns true if the autoscaler is turned on
+func (c *AutoScaleConfig) IsEnabled() bool {
+	return c != nil && (c.Enabled == nil || *c.Enabled)
+}
+
+// GetAutoScaling returns the autoscaler settings, from the deprecated autoScaleConfig boolean when
+// autoScaling is not set
+func (s *CommonWebUISpec) GetAutoScaling() *AutoScaleConfig {
+	if s.AutoScaling != nil || !s.AutoScaleConfig {
+		return s.AutoScaling
+	}
+	return &AutoScaleConfig{}
+}
+
+// Scheduling defines the placement of the common-web-ui pods. Unset fields keep the operator defaults.
+type Scheduling struct {
+	// NodeSelector limits the pods to nodes with these labels
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are added to the default tolerations, a toleration with the same key and effect
+	// as a default one replaces it
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity replaces the default affinity. Node affinity, pod affinity and pod anti-affinity are
+	// replaced separately, the defaults are kept for the ones that are not set.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// TopologySpreadConstraints are added to the default constraints, a constraint with the same
+	// topology key as a default one replaces it
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// PriorityClassName is the priority class of the pods
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// CommonWebUIStatus defines the observed state of CommonWebUI
+type CommonWebUIStatus struct {
+	// PodNames will hold the names of the commonwebui's
+	Nodes           []string      `json:"nodes"`
+	Service         ServiceStatus `json:"service,omitempty"`
+	OperatorVersion string        `json:"operatorVersion,omitempty"`
+	OperandVersion  string        `json:"operandVersion,omitempty"`
+	// ObservedGeneration is the most recent generation of the CR that the operator has reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the current state of the CommonWebUI service
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// EffectiveSpec shows the configuration the operator applies, including the defaults for unset fields
+	EffectiveSpec *EffectiveSpec `json:"effectiveSpec,omitempty"`
+	// LastDrift lists the fields of the managed resources that were changed outside of the operator
+	// and reverted by the last reconcile that found a difference
+	LastDrift *DriftReport `json:"lastDrift,omitempty"`
+}
+
+// DriftReport records when the operator reverted changes made to its managed resources
+type DriftReport struct {
+	// Time is when the changes were reverted
+	Time metav1.Time `json:"time"`
+	// Resources are the managed resources that were reverted
+	Resources []ResourceDrift `json:"resources,omitempty"`
+}
+
+// ResourceDrift lists the reverted fields of a managed resource
+type ResourceDrift struct {
+	Kind       string `json:"kind"`
+	ObjectName string `json:"objectName"`
+	// Fields are the reverted fields in the form `path: current -> desired`
+	Fields []string `json:"fields,omitempty"`
+}
+
+// EffectiveSpec holds the values used for the common-web-ui deployment after defaulting
+type EffectiveSpec struct {
+	Replicas      int32  `json:"replicas"`
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+	ImageTag      string `json:"imageTag,omitempty"`
+	// Image is the image that is deployed, it is taken from RELATED_IMAGE_COMMON_WEB_UI_IMAGE when that is set
+	Image     string                      `json:"image,omitempty"`
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// AutoScaling shows the HorizontalPodAutoscaler settings, it is not set when autoscaling is off
+	AutoScaling *EffectiveAutoScaling `json:"autoScaling,omitempty"`
+}
+
+// EffectiveAutoScaling holds the settings of the HorizontalPodAutoscaler after defaulting
+type EffectiveAutoScaling struct {
+	MinReplicas int32                                          `json:"minReplicas"`
+	MaxReplicas int32                                          `json:"maxReplicas"`
+	Metrics     []autoscalingv2.MetricSpec                     `json:"metrics,omitempty"`
+	Behavior    *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// Condition types reported in the CommonWebUI status
+const ConditionAvailable string = "Available"
+const ConditionProgressing string = "Progressing"
+const ConditionDegraded string = "Degraded"
+const ConditionReconcileSuccess string = "ReconcileSuccess"
+const ConditionWaitingForCertificate string = "WaitingForCertificate"
+const ConditionPaused string = "Paused"
+const ConditionPodDisruptionBudgetSkipped string = "PodDisruptionBudgetSkipped"
+
+// Condition reasons reported in the CommonWebUI status
+const ReasonAllResourcesReady string = "AllResourcesReady"
+const ReasonResourcesNotReady string = "ResourcesNotReady"
+const ReasonRolloutInProgress string = "RolloutInProgress"
+const ReasonRolloutComplete string = "RolloutComplete"
+const ReasonDeploymentNotFound string = "DeploymentNotFound"
+const ReasonWaitingForCertificate string = "WaitingForCertificate"
+const ReasonCertificateReady string = "CertificateReady"
+const ReasonCertificateTimeout string = "CertificateTimeout"
+const ReasonCertificateInvalid string = "CertificateInvalid"
+const ReasonClusterInfoMissing string = "ClusterInfoMissing"
+const ReasonConfigMapFailed string = "ConfigMapReconcileFailed"
+const ReasonServiceAccountFailed string = "ServiceAccountReconcileFailed"
+const ReasonCertificateFailed string = "CertificateReconcileFailed"
+const ReasonDeploymentFailed string = "DeploymentReconcileFailed"
+const ReasonServiceFailed string = "ServiceReconcileFailed"
+const ReasonRouteFailed string = "RouteReconcileFailed"
+const ReasonIngressFailed string = "IngressReconcileFailed"
+const ReasonGatewayFailed string = "GatewayReconcileFailed"
+const ReasonNavConfigFailed string = "NavConfigReconcileFailed"
+const ReasonHPAFailed string = "HorizontalPodAutoscalerReconcileFailed"
+const ReasonPDBFailed string = "PodDisruptionBudgetReconcileFailed"
+const ReasonNetworkPolicyFailed string = "NetworkPolicyReconcileFailed"
+const ReasonReconcileComplete string = "ReconcileComplete"
+const ReasonDuplicateInstance string = "DuplicateInstance"
+const ReasonPaused string = "PausedByAnnotation"
+const ReasonNotPaused string = "NotPaused"
+const ReasonSingleReplica string = "SingleReplica"
+
+// ServiceStatus struct
+type ServiceStatus struct {
+	ObjectName       string                  `json:"objectName,omitempty"`
+	APIVersion       string                  `json:"apiVersion,omitempty"`
+	Namespace        string                  `json:"namespace,omitempty"`
+	Kind             string                  `json:"kind,omitempty"`
+	ManagedResources []ManagedResourceStatus `json:"managedResources,omitempty"`
+}
+
+type ManagedResourceStatus struct {
+	ObjectName string `json:"objectName,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Status     string `json:"status,omitempty"`
+	// Unmanaged is true when the object has the unmanaged annotation and is not changed by the operator
+	Unmanaged bool `json:"unmanaged,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// CommonWebUI is the Schema for the commonwebuis API
+type CommonWebUI struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CommonWebUISpec   `json:"spec,omitempty"`
+	Status CommonWebUIStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CommonWebUIList contains a list of CommonWebUI
+type CommonWebUIList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CommonWebUI `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CommonWebUI{}, &CommonWebUIList{})
+}
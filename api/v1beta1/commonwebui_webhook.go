@@ -0,0 +1,236 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// Range accepted for spec.globalUIConfig.sessionPollingInterval in milliseconds, 0 keeps the common-web-ui default
+const MinSessionPollingInterval int32 = 1000
+const MaxSessionPollingInterval int32 = 3600000
+
+// DefaultResources returns the resources of the common-web-ui container when they are not set in the CR
+func DefaultResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1000m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("300m"),
+			corev1.ResourceMemory:           resource.MustParse("512Mi"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("251Mi"),
+		},
+	}
+}
+
+var commonwebuilog = logf.Log.WithName("commonwebui-resource")
+
+// SetupWebhookWithManager registers the validating webhook and, because v1beta1 is the hub,
+// the conversion webhook for CommonWebUI
+func (r *CommonWebUI) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-operators-ibm-com-v1beta1-commonwebui,mutating=false,failurePolicy=fail,sideEffects=None,groups=operators.ibm.com,resources=commonwebuis,verbs=create;update,versions=v1beta1,name=vcommonwebui-v1beta1.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &CommonWebUI{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *CommonWebUI) ValidateCreate() error {
+	commonwebuilog.Info("validate create", "name", r.Name, "namespace", r.Namespace)
+	return r.validateCommonWebUI()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *CommonWebUI) ValidateUpdate(old runtime.Object) error {
+	commonwebuilog.Info("validate update", "name", r.Name, "namespace", r.Namespace)
+	return r.validateCommonWebUI()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *CommonWebUI) ValidateDelete() error {
+	return nil
+}
+
+func (r *CommonWebUI) validateCommonWebUI() error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if r.Spec.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("replicas"), r.Spec.Replicas, "must be greater than or equal to 0"))
+	}
+
+	allErrs = append(allErrs, validateResources(r.Spec.Resources, specPath.Child("resources"))...)
+
+	interval := r.Spec.GlobalUIConfig.SessionPollingInterval
+	if interval != 0 && (interval < MinSessionPollingInterval || interval > MaxSessionPollingInterval) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("globalUIConfig", "sessionPollingInterval"), interval,
+			fmt.Sprintf("must be between %d and %d milliseconds", MinSessionPollingInterval, MaxSessionPollingInterval)))
+	}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabels(r.Spec.Labels, specPath.Child("labels"))...)
+
+	if r.Spec.CommonWebUIConfig.LandingPage != "" {
+		allErrs = append(allErrs, ValidateLandingPage(r.Spec.CommonWebUIConfig.LandingPage, specPath.Child("commonWebUIConfig", "landingPage"))...)
+	}
+
+	allErrs = append(allErrs, validateEnv(r.Spec.CommonWebUIConfig.Env, specPath.Child("commonWebUIConfig", "env"))...)
+
+	allErrs = append(allErrs, validateAutoScaleConfig(r.Spec.AutoScaling, specPath.Child("autoScaling"))...)
+
+	if pdb := r.Spec.PodDisruptionBudget; pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("podDisruptionBudget", "maxUnavailable"), pdb.MaxUnavailable.String(),
+			"may not be specified together with minAvailable"))
+	}
+
+	//The pods only serve HTTPS, the router cannot connect to them with edge termination
+	if route := r.Spec.Route; route != nil && route.Termination != "" &&
+		route.Termination != RouteTerminationReencrypt && route.Termination != RouteTerminationPassthrough {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("route", "termination"), route.Termination,
+			[]string{RouteTerminationReencrypt, RouteTerminationPassthrough}))
+	}
+
+	if r.Spec.Scheduling != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabels(r.Spec.Scheduling.NodeSelector, specPath.Child("scheduling", "nodeSelector"))...)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("CommonWebUI").GroupKind(), r.Name, allErrs)
+}
+
+func validateResources(resources corev1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	defaultLimits := DefaultResources().Limits
+
+	for _, name := range sortedResourceNames(resources.Limits) {
+		quantity := resources.Limits[name]
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("limits").Key(string(name)), quantity.String(), "must be greater than or equal to 0"))
+		}
+	}
+	for _, name := range sortedResourceNames(resources.Requests) {
+		quantity := resources.Requests[name]
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requests").Key(string(name)), quantity.String(), "must be greater than or equal to 0"))
+			continue
+		}
+		//Requests are compared to the limit the container gets, which is the default when the CR does not set one
+		limit, found := resources.Limits[name]
+		if !found {
+			limit, found = defaultLimits[name]
+		}
+		if found && quantity.Cmp(limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requests").Key(string(name)), quantity.String(),
+				fmt.Sprintf("must be less than or equal to %s limit of %s", name, limit.String())))
+		}
+	}
+
+	return allErrs
+}
+
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+func validateEnv(env []corev1.EnvVar, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := map[string]bool{}
+	for i, envVar := range env {
+		idxPath := fldPath.Index(i)
+		if envVar.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else {
+			for _, msg := range validation.IsEnvVarName(envVar.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), envVar.Name, msg))
+			}
+			if names[envVar.Name] {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), envVar.Name))
+			}
+			names[envVar.Name] = true
+		}
+		if envVar.Value != "" && envVar.ValueFrom != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("valueFrom"), "", "may not be specified when `value` is not empty"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAutoScaleConfig(config *AutoScaleConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if config == nil {
+		return allErrs
+	}
+
+	if config.MinReplicas != nil && *config.MinReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), *config.MinReplicas, "must be greater than or equal to 1"))
+	}
+	if config.MaxReplicas != nil && *config.MaxReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), *config.MaxReplicas, "must be greater than or equal to 1"))
+	}
+	if config.MinReplicas != nil && config.MaxReplicas != nil && *config.MaxReplicas < *config.MinReplicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), *config.MaxReplicas, "must be greater than or equal to minReplicas"))
+	}
+	if config.TargetCPUUtilizationPercentage != nil && *config.TargetCPUUtilizationPercentage < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetCPUUtilizationPercentage"), *config.TargetCPUUtilizationPercentage,
+			"must be greater than 0"))
+	}
+	if config.TargetMemoryUtilizationPercentage != nil && *config.TargetMemoryUtilizationPercentage < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetMemoryUtilizationPercentage"), *config.TargetMemoryUtilizationPercentage,
+			"must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+// ValidateLandingPage checks that a landing page is an absolute path on the console host
+func ValidateLandingPage(landingPage string, fldPath *field.Path) field.ErrorList {
+	parsed, err := url.Parse(landingPage)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, landingPage, err.Error())}
+	}
+	if parsed.Scheme != "" || parsed.Host != "" || !strings.HasPrefix(parsed.Path, "/") {
+		return field.ErrorList{field.Invalid(fldPath, landingPage, "must be an absolute path on the console host, e.g. /common-nav/dashboard")}
+	}
+	return nil
+}
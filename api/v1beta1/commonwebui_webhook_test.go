@@ -0,0 +1,170 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestValidateCommonWebUI(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(spec *CommonWebUISpec)
+		// errors lists the field paths that are expected to be rejected, an empty list means the CR is valid
+		errors []string
+	}{
+		{
+			name:   "empty spec",
+			mutate: func(spec *CommonWebUISpec) {},
+		},
+		{
+			name:   "negative replicas",
+			mutate: func(spec *CommonWebUISpec) { spec.Replicas = -1 },
+			errors: []string{"spec.replicas"},
+		},
+		{
+			name: "request within the limit",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.Resources = corev1.ResourceRequirements{
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				}
+			},
+		},
+		{
+			name: "request above the limit",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.Resources = corev1.ResourceRequirements{
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("257Mi")},
+				}
+			},
+			errors: []string{"spec.resources.requests[memory]"},
+		},
+		{
+			name: "cpu request above the default limit",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}
+			},
+			errors: []string{"spec.resources.requests[cpu]"},
+		},
+		{
+			name: "memory request above the default limit",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.Resources.Requests = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+			},
+			errors: []string{"spec.resources.requests[memory]"},
+		},
+		{
+			name: "ephemeral-storage request without a limit",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.Resources.Requests = corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("10Gi")}
+			},
+		},
+		{
+			name: "negative limit",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("-1")}
+			},
+			errors: []string{"spec.resources.limits[cpu]"},
+		},
+		{
+			name:   "session polling interval of the samples",
+			mutate: func(spec *CommonWebUISpec) { spec.GlobalUIConfig.SessionPollingInterval = 5000 },
+		},
+		{
+			name: "session polling interval below the minimum",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.GlobalUIConfig.SessionPollingInterval = MinSessionPollingInterval - 1
+			},
+			errors: []string{"spec.globalUIConfig.sessionPollingInterval"},
+		},
+		{
+			name: "session polling interval above the maximum",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.GlobalUIConfig.SessionPollingInterval = MaxSessionPollingInterval + 1
+			},
+			errors: []string{"spec.globalUIConfig.sessionPollingInterval"},
+		},
+		{
+			name:   "invalid label key",
+			mutate: func(spec *CommonWebUISpec) { spec.Labels = map[string]string{"-invalid": "value"} },
+			errors: []string{"spec.labels"},
+		},
+		{
+			name:   "relative landing page",
+			mutate: func(spec *CommonWebUISpec) { spec.CommonWebUIConfig.LandingPage = "common-nav/dashboard" },
+			errors: []string{"spec.commonWebUIConfig.landingPage"},
+		},
+		{
+			name:   "absolute landing page",
+			mutate: func(spec *CommonWebUISpec) { spec.CommonWebUIConfig.LandingPage = "/common-nav/dashboard" },
+		},
+		{
+			name: "duplicate env var",
+			mutate: func(spec *CommonWebUISpec) {
+				spec.CommonWebUIConfig.Env = []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "A", Value: "2"}}
+			},
+			errors: []string{"spec.commonWebUIConfig.env[1].name"},
+		},
+		{
+			name:   "passthrough route",
+			mutate: func(spec *CommonWebUISpec) { spec.Route = &RouteConfig{Termination: RouteTerminationPassthrough} },
+		},
+		{
+			name:   "edge route",
+			mutate: func(spec *CommonWebUISpec) { spec.Route = &RouteConfig{Termination: "edge"} },
+			errors: []string{"spec.route.termination"},
+		},
+		{
+			name: "autoscaler max below min",
+			mutate: func(spec *CommonWebUISpec) {
+				minReplicas, maxReplicas := int32(3), int32(2)
+				spec.AutoScaling = &AutoScaleConfig{MinReplicas: &minReplicas, MaxReplicas: &maxReplicas}
+			},
+			errors: []string{"spec.autoScaling.maxReplicas"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := &CommonWebUI{}
+			instance.Name = "example-commonwebui"
+			test.mutate(&instance.Spec)
+
+			err := instance.validateCommonWebUI()
+			if len(test.errors) == 0 {
+				if err != nil {
+					t.Fatalf("expected the CR to be valid, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors for %v, got none", test.errors)
+			}
+			for _, path := range test.errors {
+				if !strings.Contains(err.Error(), path) {
+					t.Errorf("expected an error for %s, got %v", path, err)
+				}
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"reflect"
 	"strings"
@@ -31,21 +32,27 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 	im "github.com/IBM/ibm-commonui-operator/apis/operator/v1alpha1"
 	res "github.com/IBM/ibm-commonui-operator/controllers/resources"
 	"github.com/IBM/ibm-commonui-operator/version"
@@ -55,9 +62,19 @@ var log = logf.Log.WithName("controller_commonwebui")
 
 // CommonWebUIReconciler reconciles a CommonWebUI object
 type CommonWebUIReconciler struct {
-	Client client.Client
-	Scheme *runtime.Scheme
-	IsCncf bool
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	IsCncf   bool
+
+	//Reads the secrets referenced by the CR from the API server, the Client is used when it is not set
+	APIReader client.Reader
+
+	//How long to wait for the certificate secret before the CR is marked degraded
+	CertWaitTimeout time.Duration
+
+	//The components reconciled for the CR, DefaultRegistry when not set
+	Registry *res.Registry
 }
 
 const finalizerName = "commonui.operators.ibm.com"
@@ -66,6 +83,7 @@ const finalizerName1 = "commonui1.operators.ibm.com"
 //+kubebuilder:rbac:groups=operators.ibm.com,resources=commonwebuis,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=operators.ibm.com,resources=commonwebuis/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=operators.ibm.com,resources=commonwebuis/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -86,37 +104,57 @@ func (r *CommonWebUIReconciler) Reconcile(ctx context.Context, request ctrl.Requ
 	needToRequeue := false
 
 	// Fetch the CommonWebUIService CR instance
-	instance := &operatorsv1alpha1.CommonWebUI{}
-
-	//If the ibmcloud-cluster-info configmap has been updated then we need to reconcile routes
-	//Since this isn't owned by our CR, we need to look our CR up
-	if request.Name == "NON_OWNED_OBJECT_RECONCILE" {
-		crList := &operatorsv1alpha1.CommonWebUIList{}
-		err := r.Client.List(ctx, crList, client.InNamespace(instance.Namespace))
-		if err != nil || len(crList.Items) == 0 {
-			reqLogger.Error(err, "Cluster config configmap has changed, but unable to load list of CommonWebUI CRs")
-			return ctrl.Result{}, err
-		}
-		instance = &crList.Items[0]
-	} else {
-		err = r.Client.Get(ctx, request.NamespacedName, instance)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				// Request object not found, could have been deleted after reconcile request.
-				// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-				// Return and don't requeue
-				return ctrl.Result{}, nil
-			}
-			// Error reading the object - requeue the request.
-			return ctrl.Result{}, err
+	instance := &operatorsv1beta1.CommonWebUI{}
+
+	err = r.Client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			res.DeleteServiceStatusMetrics(request.Namespace, request.Name)
+			return ctrl.Result{}, nil
 		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	//The managed resources have fixed names, so only one CR per namespace can manage them
+	active, err := r.getActiveInstance(ctx, instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if active != nil && active.Name != instance.Name {
+		return ctrl.Result{}, r.rejectDuplicateInstance(ctx, instance, active)
 	}
 
 	reqLogger.Info("CommonWebUI instance version: " + instance.Spec.OperatorVersion)
 
+	//Changes made through this client are recorded as events on the CR
+	k8sClient := res.NewEventClient(r.Client, r.Recorder, instance)
+
+	// Check to see if Zen instance exists in common services namespace
+	isZen := false //ZEN DISABLED res.IsAdminHubOnZen(ctx, k8sClient, instance.Namespace)
+
+	// Check to see kubernetes cluster type is cncf
+	isCncf := r.IsCncf
+
+	rc := &res.ReconcileContext{
+		Client:          k8sClient,
+		Recorder:        r.Recorder,
+		Instance:        instance,
+		IsCncf:          isCncf,
+		IsZen:           isZen,
+		APIReader:       r.APIReader,
+		CertWaitTimeout: r.CertWaitTimeout,
+	}
+
+	//Keep a copy of the status so that it is only written back when something has changed
+	originalStatus := instance.Status.DeepCopy()
+
 	//Setup status update before returning
 	defer func() {
-		err := r.updateStatus(ctx, instance)
+		err := r.updateStatus(ctx, rc, originalStatus)
 		if err != nil {
 			reqLogger.Error(err, "Error updating current CR status")
 		}
@@ -127,92 +165,93 @@ func (r *CommonWebUIReconciler) Reconcile(ctx context.Context, request ctrl.Requ
 		instance.Status.Nodes = res.DefaultStatusForCR
 		instance.Status.OperatorVersion = version.Version
 		instance.Status.OperandVersion = version.Version
-		err = r.Client.Status().Update(ctx, instance)
+		err = k8sClient.Status().Update(ctx, instance)
 		if err != nil {
 			reqLogger.Error(err, "Failed to set CommonWebUI default status")
 			return ctrl.Result{}, err
 		}
 	}
 
-	// Check to see if Zen instance exists in common services namespace
-	isZen := false //ZEN DISABLED res.IsAdminHubOnZen(ctx, r.Client, instance.Namespace)
-
-	// Check to see kubernetes cluster type is cncf
-	isCncf := r.IsCncf
+	//Show the applied configuration in the status, including the defaults for the fields that are not set in the CR
+	effectiveSpec := res.GetEffectiveSpec(instance)
+	instance.Status.EffectiveSpec = &effectiveSpec
 
-	// Check if the log4js configmap already exists. If not, create a new one.
-	err = res.ReconcileLog4jsConfigMap(ctx, r.Client, instance, &needToRequeue)
-	if err != nil {
-		return ctrl.Result{}, err
+	//The managed resources are left alone while the CR is paused, the status is still updated
+	paused := res.IsPaused(instance)
+	if paused != meta.IsStatusConditionTrue(instance.Status.Conditions, operatorsv1beta1.ConditionPaused) && r.Recorder != nil {
+		if paused {
+			r.Recorder.Event(instance, corev1.EventTypeNormal, operatorsv1beta1.ReasonPaused,
+				"Reconcile is paused by the "+res.PausedAnnotation+" annotation")
+		} else {
+			r.Recorder.Event(instance, corev1.EventTypeNormal, operatorsv1beta1.ReasonNotPaused, "Reconcile is resumed")
+		}
 	}
-
-	// Check if the common-web-ui-config configmap already exists. If not, create a new one.
-	err = res.ReconcileCommonUIConfigConfigMap(ctx, r.Client, instance, &needToRequeue)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	err = res.ReconcileServiceAccount(ctx, r.Client, instance, &needToRequeue)
-	if err != nil {
-		return ctrl.Result{}, err
+	if paused {
+		reqLogger.Info("CommonWebUI is paused - managed resources are not reconciled", "annotation", res.PausedAnnotation)
+		res.SetCondition(instance, operatorsv1beta1.ConditionPaused, metav1.ConditionTrue, operatorsv1beta1.ReasonPaused,
+			"Reconcile is paused by the "+res.PausedAnnotation+" annotation")
+		return ctrl.Result{}, nil
 	}
+	res.SetCondition(instance, operatorsv1beta1.ConditionPaused, metav1.ConditionFalse, operatorsv1beta1.ReasonNotPaused,
+		"Managed resources are reconciled")
+	res.SetPodDisruptionBudgetCondition(r.Recorder, instance)
 
 	// For 1.15.0 operator version, check if v1alpha1 certs exits on upgrade and delete if so
-	r.deleteCertsv1alpha1(ctx, instance)
+	r.deleteCertsv1alpha1(ctx, k8sClient, instance)
 
-	// Check if the certificates already exists. If not, create new v1 certs.
-	err = res.ReconcileCertificates(ctx, r.Client, instance, &needToRequeue)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
+	//Changes to the managed resources are only reported as drift when neither the CR nor the operator have
+	//changed since the last reconcile, otherwise the apply may just roll out the new configuration
+	specChanged := originalStatus.ObservedGeneration != instance.Generation || originalStatus.OperatorVersion != version.Version
+	drifts := []operatorsv1beta1.ResourceDrift{}
 
-	//Reconciliation will wait until the certificate secret has been deployed.  If the
-	// wait is not inserted, then the deployment gets updated multiple times in rapid
-	// succession which can mess up zone spreading
+	// Reconcile the components in the order of the registry. The deployment waits until the certificate
+	// secret has been deployed, if the wait is not inserted the deployment gets updated multiple times in
+	// rapid succession which can mess up zone spreading
 	// https://github.ibm.com/IBMPrivateCloud/roadmap/issues/63642
-	err = r.waitForCertSecret(ctx, r.Client, instance.Namespace)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// Check if the deployment already exists. If not, create a new one.
-	err = res.ReconcileDeployment(ctx, r.Client, instance, isZen, isCncf, &needToRequeue)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
+	for _, component := range r.registry().Resources() {
+		var result res.ComponentResult
+		err = res.TimeReconcileStep(component.Name(), func() error {
+			var componentErr error
+			result, componentErr = res.ReconcileComponent(ctx, rc, component)
+			return componentErr
+		})
+		if err != nil {
+			res.SetLastDrift(instance, drifts)
+			return r.reconcileFailed(instance, component.FailedReason(err), err)
+		}
+		reqLogger.V(1).Info("Reconciled component", "component", result.Name, "operation", result.Operation)
 
-	// Check if the service already exists. If not, create a new one.
-	err = res.ReconcileService(ctx, r.Client, instance, &needToRequeue)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
+		if result.Drift != nil && !specChanged {
+			reqLogger.Info("Reverted changes made outside of the operator", "Kind", result.Drift.Kind, "Name", result.Drift.ObjectName,
+				"fields", result.Drift.Fields)
+			drifts = append(drifts, *result.Drift)
+		}
 
-	// Reconcile the required routes if this is not a cncf cluster
-	if !isCncf {
-		err = res.ReconcileRoutes(ctx, r.Client, instance, &needToRequeue)
-		if err != nil {
-			return ctrl.Result{}, err
+		if result.RequeueAfter > 0 {
+			res.SetLastDrift(instance, drifts)
+			return ctrl.Result{RequeueAfter: result.RequeueAfter}, nil
+		}
+		if result.Operation == controllerutil.OperationResultCreated {
+			needToRequeue = true
 		}
 	}
+	res.SetLastDrift(instance, drifts)
+	res.SetProgressingCondition(ctx, k8sClient, instance)
 
 	// Remove any legacy ingresses if they are found (this would only be on migration)
 	// This was for cloudpak 3.0 work
-	res.ReconcileRemoveIngresses(ctx, r.Client, instance, &needToRequeue)
+	res.ReconcileRemoveIngresses(ctx, k8sClient, instance, &needToRequeue)
 
 	// Update admin hub nav config, if it exists.
-	err = res.ReconcileAdminHubNavConfig(ctx, r.Client, instance)
+	err = res.TimeReconcileStep("ReconcileAdminHubNavConfig", func() error {
+		return res.ReconcileAdminHubNavConfig(ctx, k8sClient, instance)
+	})
 	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// Check if the certificates already exists. If not, create new v1 certs.
-	err = res.ReconcileHorizontalPodAutoscaler(ctx, r.Client, instance, &needToRequeue)
-	if err != nil {
-		return ctrl.Result{}, err
+		return r.reconcileFailed(instance, operatorsv1beta1.ReasonNavConfigFailed, err)
 	}
 
 	// Cleanup any remaining zen artifacts after removal of adminhub
-	r.removeLegacyZenResources(ctx, instance)
+	r.removeLegacyZenResources(ctx, k8sClient, instance)
 
 	//Delete the operand request that may have been created by common ui prior to upgrade to cp 3.0
 	//nolint
@@ -220,78 +259,61 @@ func (r *CommonWebUIReconciler) Reconcile(ctx context.Context, request ctrl.Requ
 
 	r.removeLegacyFinalizers(ctx, instance)
 
+	res.SetReconcileSuccessCondition(instance)
+
 	if needToRequeue {
 		// One or more resources were created, so requeue the request
 		reqLogger.Info("Requeuing the request")
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	if rc.RequeueAfter > 0 {
+		// A self-signed certificate has to be renewed
+		reqLogger.Info("Requeuing the request", "requeueAfter", rc.RequeueAfter)
+		return ctrl.Result{RequeueAfter: rc.RequeueAfter}, nil
+	}
+
 	reqLogger.Info("COMMON UI CONTROLLER RECONCILE ALL DONE")
 	return ctrl.Result{}, nil
 }
 
-func (r *CommonWebUIReconciler) waitForCertSecret(ctx context.Context, client client.Client, ns string) error {
-
-	//Check and see if the cert secret exists ... if not, go into a wait for it
-	certSecret := &corev1.Secret{}
-	if err := client.Get(ctx, types.NamespacedName{
-		Namespace: ns,
-		Name:      "common-web-ui-cert",
-	}, certSecret); err == nil {
-		log.Info("common-web-ui-cert secret exists - reconcile will continue")
-		return nil
+// reconcileFailed records the failed reconcile step in the CR conditions and as a warning event, and returns
+// the error so the request is retried. The conditions are written by the deferred status update in Reconcile.
+func (r *CommonWebUIReconciler) reconcileFailed(instance *operatorsv1beta1.CommonWebUI, reason string, err error) (ctrl.Result, error) {
+	res.SetReconcileFailedCondition(instance, reason, err)
+	if r.Recorder != nil {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, reason, err.Error())
 	}
+	return ctrl.Result{}, err
+}
 
-	log.Info("Reconcile will wait until common-web-ui cert secret common-web-ui-cert is created")
-	timeout := time.After(5 * time.Minute)
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-timeout:
-			return errors.NewTimeoutError("Timeout waiting for common-web-ui certificate secret", 0)
-		case <-ticker.C:
-			// Check if the certificate secret exists
-			certSecret := &corev1.Secret{}
-			if err := client.Get(ctx, types.NamespacedName{
-				Namespace: ns,
-				Name:      "common-web-ui-cert",
-			}, certSecret); err != nil {
-				if errors.IsNotFound(err) {
-					log.Info("common-web-ui-cert secret not found yet, waiting...")
-					continue
-				}
-				log.Error(err, "Error getting common-web-ui-cert secret")
-				continue
-			}
-			log.Info("Common-web-ui-cert secret exists - reconcile will continue")
-			goto endWait
-		}
+// registry returns the components reconciled for the CR, the default registry is used when none is set
+func (r *CommonWebUIReconciler) registry() *res.Registry {
+	if r.Registry == nil {
+		return res.DefaultRegistry()
 	}
-endWait:
-	return nil
+	return r.Registry
 }
 
-func (r *CommonWebUIReconciler) removeLegacyZenResources(ctx context.Context, instance *operatorsv1alpha1.CommonWebUI) {
+func (r *CommonWebUIReconciler) removeLegacyZenResources(ctx context.Context, k8sClient client.Client, instance *operatorsv1beta1.CommonWebUI) {
 	reqLogger := log.WithValues("func", "removeLegacyZenResources", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 	reqLogger.Info("Removing legacy classic admin hub resources for zen")
 
 	//Delete common ui bind info config map
 	//nolint
-	res.DeleteConfigMap(ctx, r.Client, "ibm-commonui-bindinfo-common-webui-ui-extensions", instance.Namespace)
+	res.DeleteConfigMap(ctx, k8sClient, "ibm-commonui-bindinfo-common-webui-ui-extensions", instance.Namespace)
 
 	//Delete classic admin hub left nav menu item
 	//nolint
-	res.DeleteConfigMap(ctx, r.Client, res.ZenLeftNavExtensionsConfigMapName, instance.Namespace)
+	res.DeleteConfigMap(ctx, k8sClient, res.ZenLeftNavExtensionsConfigMapName, instance.Namespace)
 
 	//Delete zen adminhub card extensions
 	//nolint
-	res.DeleteConfigMap(ctx, r.Client, res.ZenCardExtensionsConfigMapName, instance.Namespace)
+	res.DeleteConfigMap(ctx, k8sClient, res.ZenCardExtensionsConfigMapName, instance.Namespace)
 
 	//Delete zen adminhub quick nav extensions
 	//nolint
-	res.DeleteConfigMap(ctx, r.Client, res.ZenQuickNavExtensionsConfigMapName, instance.Namespace)
+	res.DeleteConfigMap(ctx, k8sClient, res.ZenQuickNavExtensionsConfigMapName, instance.Namespace)
 
 }
 
@@ -299,7 +321,7 @@ func (r *CommonWebUIReconciler) removeLegacyZenResources(ctx context.Context, in
 // These are causing issues on 4.x upgrade because the finalizers still exist, however the code
 // that would process them is long removed (this is because console links require cluster permissions
 // and were essentially abandoned as objects in 4.x - customer must remove them if one exists)
-func (r *CommonWebUIReconciler) removeLegacyFinalizers(ctx context.Context, instance *operatorsv1alpha1.CommonWebUI) {
+func (r *CommonWebUIReconciler) removeLegacyFinalizers(ctx context.Context, instance *operatorsv1beta1.CommonWebUI) {
 	reqLogger := log.WithValues("func", "removeLegacyFinalizers", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 	reqLogger.Info("Checking for legacy finalizers for removal")
 
@@ -316,13 +338,16 @@ func (r *CommonWebUIReconciler) removeLegacyFinalizers(ctx context.Context, inst
 			reqLogger.Info("Removing finalizer " + finalizerName1)
 		}
 
+		//The update response overwrites the status in memory, keep the conditions set by this reconcile
+		status := instance.Status.DeepCopy()
 		if err := r.Client.Update(ctx, instance); err != nil {
 			reqLogger.Error(err, "Failed to update after removing finalizer")
 		}
+		instance.Status = *status
 	}
 }
 
-func (r *CommonWebUIReconciler) deleteCertsv1alpha1(ctx context.Context, instance *operatorsv1alpha1.CommonWebUI) {
+func (r *CommonWebUIReconciler) deleteCertsv1alpha1(ctx context.Context, k8sClient client.Client, instance *operatorsv1beta1.CommonWebUI) {
 	reqLogger := log.WithValues("func", "deleteCertsv1alpha1", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 
 	certificate := &certmgrv1alpha1.Certificate{
@@ -331,7 +356,7 @@ func (r *CommonWebUIReconciler) deleteCertsv1alpha1(ctx context.Context, instanc
 			Namespace: instance.Namespace,
 		},
 	}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: res.UICertName, Namespace: instance.Namespace}, certificate)
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: res.UICertName, Namespace: instance.Namespace}, certificate)
 
 	if err != nil {
 		if !errors.IsNotFound(err) {
@@ -343,7 +368,7 @@ func (r *CommonWebUIReconciler) deleteCertsv1alpha1(ctx context.Context, instanc
 	reqLogger.Info("API version is: " + certificate.APIVersion)
 	if certificate.APIVersion == res.Certv1alpha1APIVersion {
 		reqLogger.Info("deleting cert: " + res.UICertName)
-		err = r.Client.Delete(ctx, certificate)
+		err = k8sClient.Delete(ctx, certificate)
 		if err != nil {
 			reqLogger.Error(err, "Failed to delete")
 		} else {
@@ -354,21 +379,44 @@ func (r *CommonWebUIReconciler) deleteCertsv1alpha1(ctx context.Context, instanc
 	}
 }
 
-func (r *CommonWebUIReconciler) updateStatus(ctx context.Context, instance *operatorsv1alpha1.CommonWebUI) error {
+func (r *CommonWebUIReconciler) updateStatus(ctx context.Context, rc *res.ReconcileContext, originalStatus *operatorsv1beta1.CommonWebUIStatus) error {
+	instance := rc.Instance
 	reqLogger := log.WithValues("func", "updateStatus", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 	reqLogger.Info("Updating CommonWebUI status")
 
 	updateServiceStatus := false
 	updateNodeStatus := false
+	updateConditions := false
+	updateEffectiveSpec := false
+	updateLastDrift := false
 
 	//Check for updates to service status
 	reqLogger.Info("Gather current service status")
-	currentServiceStatus := res.GetCurrentServiceStatus(ctx, r.Client, instance, r.IsCncf)
+	currentServiceStatus := r.registry().GetServiceStatus(ctx, rc)
 	if !reflect.DeepEqual(currentServiceStatus, instance.Status.Service) {
+		res.RecordUnmanagedEvents(r.Recorder, instance, instance.Status.Service, currentServiceStatus)
 		instance.Status.Service = currentServiceStatus
 		updateServiceStatus = true
 	}
 
+	//Check for updates to the conditions
+	res.SetAvailableCondition(instance, currentServiceStatus)
+	instance.Status.ObservedGeneration = instance.Generation
+	if !reflect.DeepEqual(instance.Status.Conditions, originalStatus.Conditions) ||
+		instance.Status.ObservedGeneration != originalStatus.ObservedGeneration {
+		updateConditions = true
+	}
+
+	//Check for updates to the effective spec, quantities are compared by value
+	if !equality.Semantic.DeepEqual(instance.Status.EffectiveSpec, originalStatus.EffectiveSpec) {
+		updateEffectiveSpec = true
+	}
+
+	//Check for a new drift report
+	if !reflect.DeepEqual(instance.Status.LastDrift, originalStatus.LastDrift) {
+		updateLastDrift = true
+	}
+
 	//Check for updates to node (pods) status
 	podList := &corev1.PodList{}
 	listOpts := []client.ListOption{
@@ -394,9 +442,12 @@ func (r *CommonWebUIReconciler) updateStatus(ctx context.Context, instance *oper
 		reqLogger.Error(err, "Failed to list pods - CR status will not be updated")
 	}
 
+	res.RecordServiceStatusMetrics(instance, currentServiceStatus)
+
 	//Update any serivce status updates
-	if updateServiceStatus || updateNodeStatus {
-		reqLogger.Info("Updating status", "updateServiceStatus", updateServiceStatus, "updateNodeStatus", updateNodeStatus)
+	if updateServiceStatus || updateNodeStatus || updateConditions || updateEffectiveSpec || updateLastDrift {
+		reqLogger.Info("Updating status", "updateServiceStatus", updateServiceStatus, "updateNodeStatus", updateNodeStatus,
+			"updateConditions", updateConditions, "updateEffectiveSpec", updateEffectiveSpec, "updateLastDrift", updateLastDrift)
 		err := r.Client.Status().Update(ctx, instance)
 		if err != nil {
 			return err
@@ -408,6 +459,88 @@ func (r *CommonWebUIReconciler) updateStatus(ctx context.Context, instance *oper
 	return nil
 }
 
+// getActiveInstance returns the CR that manages the resources in the namespace, it is the oldest CR
+func (r *CommonWebUIReconciler) getActiveInstance(ctx context.Context, namespace string) (*operatorsv1beta1.CommonWebUI, error) {
+	crList := &operatorsv1beta1.CommonWebUIList{}
+	err := r.Client.List(ctx, crList, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	var active *operatorsv1beta1.CommonWebUI
+	for i := range crList.Items {
+		cr := &crList.Items[i]
+		if cr.DeletionTimestamp != nil {
+			continue
+		}
+		if active == nil || cr.CreationTimestamp.Before(&active.CreationTimestamp) ||
+			(cr.CreationTimestamp.Equal(&active.CreationTimestamp) && cr.Name < active.Name) {
+			active = cr
+		}
+	}
+	return active, nil
+}
+
+// rejectDuplicateInstance marks a CR that is not the active CR of its namespace as degraded, it is reconciled
+// again when the active CR is deleted
+func (r *CommonWebUIReconciler) rejectDuplicateInstance(ctx context.Context, instance, active *operatorsv1beta1.CommonWebUI) error {
+	reqLogger := log.WithValues("func", "rejectDuplicateInstance", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	err := fmt.Errorf("only one CommonWebUI is supported per namespace, CommonWebUI %s manages the common-web-ui resources in namespace %s",
+		active.Name, instance.Namespace)
+	reqLogger.Info("Duplicate CommonWebUI - the managed resources are not reconciled", "active", active.Name)
+
+	degraded := meta.FindStatusCondition(instance.Status.Conditions, operatorsv1beta1.ConditionDegraded)
+	if degraded != nil && degraded.Status == metav1.ConditionTrue && degraded.Reason == operatorsv1beta1.ReasonDuplicateInstance &&
+		degraded.Message == err.Error() && instance.Status.ObservedGeneration == instance.Generation {
+		return nil
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, operatorsv1beta1.ReasonDuplicateInstance, err.Error())
+	}
+	res.SetReconcileFailedCondition(instance, operatorsv1beta1.ReasonDuplicateInstance, err)
+	instance.Status.ObservedGeneration = instance.Generation
+	if instance.Status.Nodes == nil {
+		instance.Status.Nodes = res.DefaultStatusForCR
+	}
+	return r.Client.Status().Update(ctx, instance)
+}
+
+// enqueueCommonWebUIs maps an object that is not owned by a CR to all CRs in its namespace
+func (r *CommonWebUIReconciler) enqueueCommonWebUIs(obj client.Object) []ctrl.Request {
+	crList := &operatorsv1beta1.CommonWebUIList{}
+	err := r.Client.List(context.Background(), crList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Unable to list CommonWebUI CRs for a watched object", "namespace", obj.GetNamespace(), "name", obj.GetName())
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(crList.Items))
+	for _, cr := range crList.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}})
+	}
+	return requests
+}
+
+// A deleted CR can leave another CR of its namespace as the active one, so the remaining CRs are reconciled
+func commonWebUIDeletePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
 func clusterInfoCmPredicate() predicate.Predicate {
 	namespaces := strings.Split(os.Getenv("WATCH_NAMESPACE"), ",")
 
@@ -427,6 +560,37 @@ func clusterInfoCmPredicate() predicate.Predicate {
 	}
 }
 
+// The certificate secret is created by cert-manager and is not owned by the CR, watch it so the reconcile
+// that is waiting for it continues as soon as it is issued, and a renewed certificate is rolled out. The
+// secrets provided in the CR are not in the cache, they are read again periodically.
+func certSecretPredicate() predicate.Predicate {
+	namespaces := strings.Split(os.Getenv("WATCH_NAMESPACE"), ",")
+
+	certSecret := func(obj client.Object) bool {
+		return obj.GetName() == res.UICertSecretName && res.ContainsString(namespaces, obj.GetNamespace())
+	}
+
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSecret, oldOk := e.ObjectOld.(*corev1.Secret)
+			newSecret, newOk := e.ObjectNew.(*corev1.Secret)
+			if oldOk && newOk && reflect.DeepEqual(oldSecret.Data, newSecret.Data) {
+				return false
+			}
+			return certSecret(e.ObjectNew)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return certSecret(e.Object)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return certSecret(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
 func hpaPredicate() predicate.Predicate {
 	namespaces := strings.Split(os.Getenv("WATCH_NAMESPACE"), ",")
 	reqLogger := log.WithName("HPAPredicate")
@@ -490,41 +654,55 @@ func (r *CommonWebUIReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		}
 	}
 
+	//cert-manager does not have to be installed when the certificate secret is provided in the CR
+	_, err := r.Client.RESTMapper().RESTMapping(certmgr.GroupVersion.WithKind("Certificate").GroupKind(), certmgr.GroupVersion.Version)
+	hasCertManager := err == nil
+	if !hasCertManager {
+		setupLog.Info("cert-manager Certificate API is not present; skipping Certificate watch")
+	}
+
+	//The NetworkPolicy is only watched when the operator may manage it, a watch without permissions never syncs
+	hasNetworkPolicyAccess := true
+	for _, ns := range watchedNamespaces {
+		hasAccess, err := res.HasAPIAccess(ctx, r.Client, ns, "networking.k8s.io", "networkpolicies",
+			[]string{"get", "list", "watch", "create", "delete", "update", "patch"})
+		if err != nil || !hasAccess {
+			setupLog.Info("Missing required NetworkPolicy permissions; skipping NetworkPolicy watch", "namespace", ns)
+			hasNetworkPolicyAccess = false
+			break
+		}
+	}
+
+	//The HTTPRoute of the Gateway exposure mode is only watched when the Gateway API is installed
+	httpRoute := &unstructured.Unstructured{}
+	httpRouteGVK, hasGatewayAPI := res.GetGatewayAPIKind(r.Client, res.HTTPRouteGVK)
+	httpRoute.SetGroupVersionKind(httpRouteGVK)
+
 	//Skip routes when it is cncf
 	if r.IsCncf {
 
 		cncfBuilder := ctrl.NewControllerManagedBy(mgr).
-			For(&operatorsv1alpha1.CommonWebUI{}).
+			For(&operatorsv1beta1.CommonWebUI{}).
+			Watches(&source.Kind{Type: &operatorsv1beta1.CommonWebUI{}},
+				handler.EnqueueRequestsFromMapFunc(r.enqueueCommonWebUIs), builder.WithPredicates(commonWebUIDeletePredicate())).
 			Owns(&corev1.ConfigMap{}).
 			Owns(&appsv1.Deployment{}).
 			Owns(&corev1.Service{}).
 			Owns(&corev1.Secret{}).
-			Owns(&certmgr.Certificate{}).
 			Owns(&corev1.ServiceAccount{}).
 			Owns(&rbacv1.Role{}).
 			Owns(&rbacv1.RoleBinding{}).
+			Owns(&policyv1.PodDisruptionBudget{}).
 			//Currently was having issues with reconciling autoscaling,
 			//getting too many updates so we converted this to the predicate
 			//below
 			//Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
 			Watches(&source.Kind{Type: &corev1.ConfigMap{}},
-				handler.EnqueueRequestsFromMapFunc(func(a client.Object) []ctrl.Request {
-					return []ctrl.Request{
-						{NamespacedName: types.NamespacedName{
-							Name:      "NON_OWNED_OBJECT_RECONCILE",
-							Namespace: a.GetNamespace(),
-						}},
-					}
-				}), builder.WithPredicates(clusterInfoCmPredicate())).
+				handler.EnqueueRequestsFromMapFunc(r.enqueueCommonWebUIs), builder.WithPredicates(clusterInfoCmPredicate())).
+			Watches(&source.Kind{Type: &corev1.Secret{}},
+				handler.EnqueueRequestsFromMapFunc(r.enqueueCommonWebUIs), builder.WithPredicates(certSecretPredicate())).
 			Watches(&source.Kind{Type: &autoscalingv2.HorizontalPodAutoscaler{}},
-				handler.EnqueueRequestsFromMapFunc(func(a client.Object) []ctrl.Request {
-					return []ctrl.Request{
-						{NamespacedName: types.NamespacedName{
-							Name:      "NON_OWNED_OBJECT_RECONCILE",
-							Namespace: a.GetNamespace(),
-						}},
-					}
-				}), builder.WithPredicates(hpaPredicate()))
+				handler.EnqueueRequestsFromMapFunc(r.enqueueCommonWebUIs), builder.WithPredicates(hpaPredicate()))
 
 		// Only add Ingress watch if we have permissions
 		if hasIngressAccess {
@@ -532,6 +710,19 @@ func (r *CommonWebUIReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			cncfBuilder.Owns(&netv1.Ingress{})
 		}
 
+		if hasCertManager {
+			cncfBuilder.Owns(&certmgr.Certificate{})
+		}
+
+		if hasNetworkPolicyAccess {
+			cncfBuilder.Owns(&netv1.NetworkPolicy{})
+		}
+
+		if hasGatewayAPI {
+			setupLog.V(1).Info("Gateway API present; setting up HTTPRoute watch")
+			cncfBuilder.Owns(httpRoute)
+		}
+
 		return cncfBuilder.Complete(r)
 	}
 
@@ -577,46 +768,29 @@ func (r *CommonWebUIReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	openshiftBuilder := ctrl.NewControllerManagedBy(mgr).
-		For(&operatorsv1alpha1.CommonWebUI{}).
+		For(&operatorsv1beta1.CommonWebUI{}).
+		Watches(&source.Kind{Type: &operatorsv1beta1.CommonWebUI{}},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCommonWebUIs), builder.WithPredicates(commonWebUIDeletePredicate())).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.Secret{}).
-		Owns(&certmgr.Certificate{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		//Currently was having issues with reconciling autoscaling,
 		//getting too many updates so we converted this to the predicate
 		//below
 		//Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
 		Watches(&source.Kind{Type: &corev1.ConfigMap{}},
-			handler.EnqueueRequestsFromMapFunc(func(a client.Object) []ctrl.Request {
-				return []ctrl.Request{
-					{NamespacedName: types.NamespacedName{
-						Name:      "NON_OWNED_OBJECT_RECONCILE",
-						Namespace: a.GetNamespace(),
-					}},
-				}
-			}), builder.WithPredicates(clusterInfoCmPredicate())).
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCommonWebUIs), builder.WithPredicates(clusterInfoCmPredicate())).
+		Watches(&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCommonWebUIs), builder.WithPredicates(certSecretPredicate())).
 		Watches(&source.Kind{Type: &im.Authentication{}},
-			handler.EnqueueRequestsFromMapFunc(func(a client.Object) []ctrl.Request {
-				return []ctrl.Request{
-					{NamespacedName: types.NamespacedName{
-						Name:      "NON_OWNED_OBJECT_RECONCILE",
-						Namespace: a.GetNamespace(),
-					}},
-				}
-			})).
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCommonWebUIs)).
 		Watches(&source.Kind{Type: &autoscalingv2.HorizontalPodAutoscaler{}},
-			handler.EnqueueRequestsFromMapFunc(func(a client.Object) []ctrl.Request {
-				return []ctrl.Request{
-					{NamespacedName: types.NamespacedName{
-						Name:      "NON_OWNED_OBJECT_RECONCILE",
-						Namespace: a.GetNamespace(),
-					}},
-				}
-			}), builder.WithPredicates(hpaPredicate()))
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCommonWebUIs), builder.WithPredicates(hpaPredicate()))
 
 	// Only add Route watch if we have permissions
 	if hasRouteAccess && hasCustomHostAccess {
@@ -630,5 +804,18 @@ func (r *CommonWebUIReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		openshiftBuilder.Owns(&netv1.Ingress{})
 	}
 
+	if hasCertManager {
+		openshiftBuilder.Owns(&certmgr.Certificate{})
+	}
+
+	if hasNetworkPolicyAccess {
+		openshiftBuilder.Owns(&netv1.NetworkPolicy{})
+	}
+
+	if hasGatewayAPI {
+		setupLog.V(1).Info("Gateway API present; setting up HTTPRoute watch")
+		openshiftBuilder.Owns(httpRoute)
+	}
+
 	return openshiftBuilder.Complete(r)
 }
@@ -0,0 +1,71 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	res "github.com/IBM/ibm-commonui-operator/controllers/resources"
+)
+
+func TestCertSecretPredicate(t *testing.T) {
+	const namespace = "ibm-common-services"
+	t.Setenv("WATCH_NAMESPACE", namespace)
+
+	p := certSecretPredicate()
+
+	secret := func(name, namespace, crt string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte(crt)},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		want   bool
+	}{
+		{name: "certificate secret", secret: secret(res.UICertSecretName, namespace, "crt"), want: true},
+		{name: "other secret", secret: secret("other", namespace, "crt")},
+		{name: "certificate secret in another namespace", secret: secret(res.UICertSecretName, "other", "crt")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := p.Create(event.CreateEvent{Object: test.secret}); got != test.want {
+				t.Errorf("create: expected %t, got %t", test.want, got)
+			}
+			if got := p.Delete(event.DeleteEvent{Object: test.secret}); got != test.want {
+				t.Errorf("delete: expected %t, got %t", test.want, got)
+			}
+			renewed := secret(test.secret.Name, test.secret.Namespace, "renewed")
+			if got := p.Update(event.UpdateEvent{ObjectOld: test.secret, ObjectNew: renewed}); got != test.want {
+				t.Errorf("update of the data: expected %t, got %t", test.want, got)
+			}
+			relabeled := test.secret.DeepCopy()
+			relabeled.Labels = map[string]string{"changed": "true"}
+			if p.Update(event.UpdateEvent{ObjectOld: test.secret, ObjectNew: relabeled}) {
+				t.Errorf("update without a data change: expected false, got true")
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// FieldManager is the field manager of the server-side applies done by the operator
+const FieldManager = "ibm-commonui-operator"
+
+// legacyFieldManagers are the field managers of the updates made by operator versions before server-side
+// apply. Client-go uses the name of the binary. Other managers, like the manager binary of kubebuilder
+// based controllers, must not be listed, their fields would be removed by the next apply.
+var legacyFieldManagers = map[string]bool{"ibm-commonui-operator": true}
+
+// applyObserver is implemented by the client wrappers that report the outcome of an apply. The patch
+// itself does not tell whether the apply changed anything, so ApplyResource reports it to them.
+type applyObserver interface {
+	observeApply(obj client.Object, result controllerutil.OperationResult)
+}
+
+// ApplyResource server-side applies the desired state of a managed resource. Only the fields that are set
+// in the desired object are owned by the operator, fields set by others (for example the NamespaceScope
+// annotation, the cert-manager restart label or the replicas managed by the HPA) are left alone.
+// The result tells whether the resource was created, updated or was already up to date.
+func ApplyResource(ctx context.Context, c client.Client, desired client.Object) (controllerutil.OperationResult, error) {
+	result, _, err := applyResource(ctx, c, desired)
+	return result, err
+}
+
+// applyResource applies the desired state and also returns the fields the apply changed on an existing resource
+func applyResource(ctx context.Context, c client.Client, desired client.Object) (controllerutil.OperationResult, []string, error) {
+	gvk, err := apiutil.GVKForObject(desired, c.Scheme())
+	if err != nil {
+		return controllerutil.OperationResultNone, nil, err
+	}
+
+	//Read the current state to find out if the apply changed anything, a new object is used so no field
+	//of the desired state is left over when the current resource does not have it
+	var existing client.Object
+	if _, ok := desired.(*unstructured.Unstructured); ok {
+		//The kinds that are not in the scheme, like the Gateway API, are managed as unstructured objects
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		existing = u
+	} else {
+		newObject, err := c.Scheme().New(gvk)
+		if err != nil {
+			return controllerutil.OperationResultNone, nil, err
+		}
+		existing = newObject.(client.Object)
+	}
+	currentResourceVersion := ""
+	err = c.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err == nil {
+		//The fields written by updates of earlier operator versions are taken over by the apply, otherwise
+		//the fields that are no longer in the desired state would never be removed
+		if err = upgradeManagedFields(ctx, c, existing); err != nil {
+			return controllerutil.OperationResultNone, nil, err
+		}
+		currentResourceVersion = existing.GetResourceVersion()
+	} else if !errors.IsNotFound(err) {
+		return controllerutil.OperationResultNone, nil, err
+	}
+
+	//The apply configuration needs the type information and must not carry server populated metadata
+	desired.GetObjectKind().SetGroupVersionKind(gvk)
+	desired.SetResourceVersion("")
+	desired.SetManagedFields(nil)
+
+	err = c.Patch(ctx, desired, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+	if err != nil {
+		return controllerutil.OperationResultNone, nil, err
+	}
+
+	result := controllerutil.OperationResultNone
+	var changedFields []string
+	if currentResourceVersion == "" {
+		result = controllerutil.OperationResultCreated
+	} else if desired.GetResourceVersion() != currentResourceVersion {
+		result = controllerutil.OperationResultUpdated
+		changedFields, err = diffObjects(existing, desired)
+		if err != nil {
+			log.Error(err, "Unable to compare the resource before and after the apply", "Kind", gvk.Kind, "Name", desired.GetName())
+		}
+	}
+
+	if observer, ok := c.(applyObserver); ok && result != controllerutil.OperationResultNone {
+		observer.observeApply(desired, result)
+	}
+	return result, changedFields, nil
+}
+
+// upgradeManagedFields moves the fields owned by the updates of earlier operator versions to the apply field
+// manager of the operator, the same way as client-go's csaupgrade. It only patches the object when it still
+// has a managedFields entry of such an update, so it runs once per object.
+func upgradeManagedFields(ctx context.Context, c client.Client, obj client.Object) error {
+	entries := obj.GetManagedFields()
+
+	applyIndex := -1
+	var legacyEntries []metav1.ManagedFieldsEntry
+	upgraded := make([]metav1.ManagedFieldsEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Operation == metav1.ManagedFieldsOperationUpdate && legacyFieldManagers[entry.Manager] && entry.Subresource == "" {
+			legacyEntries = append(legacyEntries, entry)
+			continue
+		}
+		if entry.Operation == metav1.ManagedFieldsOperationApply && entry.Manager == FieldManager && entry.Subresource == "" {
+			applyIndex = len(upgraded)
+		}
+		upgraded = append(upgraded, entry)
+	}
+	if len(legacyEntries) == 0 {
+		return nil
+	}
+
+	if applyIndex < 0 {
+		upgraded = append(upgraded, metav1.ManagedFieldsEntry{
+			Manager:    FieldManager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			APIVersion: legacyEntries[0].APIVersion,
+			FieldsType: "FieldsV1",
+		})
+		applyIndex = len(upgraded) - 1
+	}
+	applyEntry := &upgraded[applyIndex]
+
+	fields := &fieldpath.Set{}
+	for _, entry := range append([]metav1.ManagedFieldsEntry{*applyEntry}, legacyEntries...) {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		entryFields := &fieldpath.Set{}
+		if err := entryFields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return err
+		}
+		fields = fields.Union(entryFields)
+	}
+	raw, err := fields.ToJSON()
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	applyEntry.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+	applyEntry.Time = &now
+
+	//The resource version makes the patch fail instead of overwriting the entries of a concurrent write
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "replace", "path": "/metadata/managedFields", "value": upgraded},
+		{"op": "replace", "path": "/metadata/resourceVersion", "value": obj.GetResourceVersion()},
+	})
+	if err != nil {
+		return err
+	}
+	kind := ""
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	log.Info("Moving the fields of earlier operator versions to the server-side apply field manager", "Kind", kind, "Name", obj.GetName())
+	return c.Patch(ctx, obj, client.RawPatch(types.JSONPatchType, patch))
+}
@@ -0,0 +1,123 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+func TestUpgradeManagedFields(t *testing.T) {
+	ctx := context.Background()
+
+	updateFields := `{"f:data":{".":{},"f:old":{}},"f:metadata":{"f:labels":{".":{},"f:app":{}}}}`
+	applyFields := `{"f:data":{"f:new":{}}}`
+	otherFields := `{"f:data":{"f:other":{}}}`
+	controllerFields := `{"f:metadata":{"f:annotations":{".":{},"f:controller":{}}}}`
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "common-web-ui-config",
+			Namespace: "cs",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "v1", FieldsType: "FieldsV1",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(updateFields)}},
+				{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationApply, APIVersion: "v1", FieldsType: "FieldsV1",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(applyFields)}},
+				{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "v1", FieldsType: "FieldsV1",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(otherFields)}},
+				//manager is the default field manager of other kubebuilder based controllers
+				{Manager: "manager", Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "v1", FieldsType: "FieldsV1",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(controllerFields)}},
+			},
+		},
+		Data: map[string]string{"old": "1", "new": "2", "other": "3"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configMap).Build()
+
+	existing := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(configMap), existing); err != nil {
+		t.Fatal(err)
+	}
+	if err := upgradeManagedFields(ctx, c, existing); err != nil {
+		t.Fatalf("upgrading the managed fields: %v", err)
+	}
+
+	upgraded := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(configMap), upgraded); err != nil {
+		t.Fatal(err)
+	}
+	entries := upgraded.GetManagedFields()
+	if len(entries) != 3 {
+		t.Fatalf("expected the apply, kubectl-edit and manager entries, got %+v", entries)
+	}
+
+	var applyEntry, otherEntry, controllerEntry *metav1.ManagedFieldsEntry
+	for i := range entries {
+		switch entries[i].Manager {
+		case FieldManager:
+			applyEntry = &entries[i]
+		case "kubectl-edit":
+			otherEntry = &entries[i]
+		case "manager":
+			controllerEntry = &entries[i]
+		}
+	}
+	if applyEntry == nil || applyEntry.Operation != metav1.ManagedFieldsOperationApply {
+		t.Fatalf("expected an apply entry of %s, got %+v", FieldManager, entries)
+	}
+	if otherEntry == nil || string(otherEntry.FieldsV1.Raw) != otherFields {
+		t.Errorf("expected the fields of other managers to be kept, got %+v", entries)
+	}
+	if controllerEntry == nil || string(controllerEntry.FieldsV1.Raw) != controllerFields {
+		t.Errorf("expected the fields of other controllers to be kept, got %+v", entries)
+	}
+
+	fields := &fieldpath.Set{}
+	if err := fields.FromJSON(bytes.NewReader(applyEntry.FieldsV1.Raw)); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []fieldpath.Path{
+		fieldpath.MakePathOrDie("data", "old"),
+		fieldpath.MakePathOrDie("data", "new"),
+		fieldpath.MakePathOrDie("metadata", "labels", "app"),
+	} {
+		if !fields.Has(path) {
+			t.Errorf("expected the apply entry to own %s, got %s", path.String(), string(applyEntry.FieldsV1.Raw))
+		}
+	}
+	if fields.Has(fieldpath.MakePathOrDie("data", "other")) || fields.Has(fieldpath.MakePathOrDie("metadata", "annotations", "controller")) {
+		t.Errorf("expected the apply entry not to own the fields of other managers, got %s", string(applyEntry.FieldsV1.Raw))
+	}
+
+	//A second run has nothing to upgrade and does not patch the object
+	resourceVersion := upgraded.GetResourceVersion()
+	if err := upgradeManagedFields(ctx, c, upgraded); err != nil {
+		t.Fatal(err)
+	}
+	if upgraded.GetResourceVersion() != resourceVersion {
+		t.Errorf("expected no patch when there is nothing to upgrade")
+	}
+}
@@ -18,19 +18,28 @@ package resources
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	errorf "errors"
+	"fmt"
 	"time"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 	// certmgr "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
 	certmgr "github.com/ibm/ibm-cert-manager-operator/apis/cert-manager/v1"
 	cmmeta "github.com/ibm/ibm-cert-manager-operator/apis/meta.cert-manager/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// ErrCertificateInvalid is returned when the certificate secret provided in the CR cannot be used
+var ErrCertificateInvalid = errorf.New("certificate secret is invalid")
+
 type CertificateData struct {
 	Name      string
 	Secret    string
@@ -40,7 +49,7 @@ type CertificateData struct {
 }
 
 // nolint
-func getDesiredCertificate(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, data CertificateData) (*certmgr.Certificate, error) {
+func getDesiredCertificate(ctx context.Context, client client.Client, instance *operatorsv1beta1.CommonWebUI, data CertificateData) (*certmgr.Certificate, error) {
 	reqLogger := log.WithValues("func", "getDesiredCertificate", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 
 	metaLabels := map[string]string{
@@ -53,6 +62,13 @@ func getDesiredCertificate(ctx context.Context, client client.Client, instance *
 		"manage-cert-rotation":         "true",
 	}
 
+	config := getCertificateConfig(instance)
+
+	duration := &metav1.Duration{Duration: getCertificateDuration(config)}
+	renewBefore := &metav1.Duration{Duration: getCertificateRenewBefore(config)}
+
+	dnsNames := getCertificateDNSNames(data.Common, instance.Namespace, config)
+
 	certificate := &certmgr.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      data.Name,
@@ -60,28 +76,30 @@ func getDesiredCertificate(ctx context.Context, client client.Client, instance *
 			Namespace: instance.Namespace,
 		},
 		Spec: certmgr.CertificateSpec{
-			CommonName: data.Common,
-			SecretName: data.Secret,
-			IsCA:       false,
-			DNSNames: []string{
-				data.Common,
-				data.Common + "." + instance.Namespace,
-				data.Common + "." + instance.Namespace + ".svc.cluster.local",
-			},
+			CommonName:  data.Common,
+			SecretName:  data.Secret,
+			IsCA:        false,
+			DNSNames:    dnsNames,
+			IPAddresses: config.IPAddresses,
 			// Organization: []string{"IBM"},
 			IssuerRef: cmmeta.ObjectReference{
-				Name: DefaultClusterIssuer,
-				Kind: certmgr.IssuerKind,
-			},
-			Duration: &metav1.Duration{
-				Duration: 9552 * time.Hour, /* 398 days */
-			},
-			RenewBefore: &metav1.Duration{
-				Duration: 2880 * time.Hour, /* 120 days (3 months) */
+				Name: GetStringWithDefault(config.IssuerName, DefaultClusterIssuer),
+				Kind: GetStringWithDefault(config.IssuerKind, certmgr.IssuerKind),
 			},
+			Duration:    duration,
+			RenewBefore: renewBefore,
 		},
 	}
 
+	//The private key settings of cert-manager are used unless they are set in the CR
+	if config.PrivateKeyAlgorithm != "" || config.PrivateKeySize != 0 || config.RotationPolicy != "" {
+		certificate.Spec.PrivateKey = &certmgr.CertificatePrivateKey{
+			Algorithm:      certmgr.PrivateKeyAlgorithm(config.PrivateKeyAlgorithm),
+			Size:           config.PrivateKeySize,
+			RotationPolicy: certmgr.PrivateKeyRotationPolicy(config.RotationPolicy),
+		}
+	}
+
 	err := controllerutil.SetControllerReference(instance, certificate, client.Scheme())
 	if err != nil {
 		reqLogger.Error(err, "Failed to set owner for certificate")
@@ -91,66 +109,195 @@ func getDesiredCertificate(ctx context.Context, client client.Client, instance *
 	return certificate, nil
 }
 
-func ReconcileCertificates(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileCertificates", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling certificates")
+// IsCertificateProvided returns true when the certificate secret of the common-web-ui pods is provided in
+// the CR instead of being issued by cert-manager
+func IsCertificateProvided(instance *operatorsv1beta1.CommonWebUI) bool {
+	return instance.Spec.Certificate != nil && instance.Spec.Certificate.SecretName != ""
+}
 
-	certs := []CertificateData{
-		UICertificateData,
+// GetCertificateSecretName returns the secret with the certificate of the common-web-ui pods
+func GetCertificateSecretName(instance *operatorsv1beta1.CommonWebUI) string {
+	if IsCertificateProvided(instance) {
+		return instance.Spec.Certificate.SecretName
 	}
+	return UICertSecretName
+}
 
-	for _, certData := range certs {
-		reqLogger.Info("Checking certificate", "Certificate.Name", certData.Name)
+// certificateResource is the certificate of the common-web-ui pods, cert-manager issues it into the
+// common-web-ui-cert secret. It is not created when the certificate secret is provided in the CR or when
+// cert-manager is not installed.
+type certificateResource struct{}
 
-		certificate := &certmgr.Certificate{}
+func (certificateResource) Name() string {
+	return "Certificate"
+}
 
-		desiredCertificate, desiredErr := getDesiredCertificate(ctx, client, instance, certData)
-		if desiredErr != nil {
-			return desiredErr
-		}
+func (certificateResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonCertificateFailed
+}
+
+func (certificateResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return !IsCertificateProvided(rc.Instance) && !IsSelfSignedCertificate(rc)
+}
+
+func (certificateResource) Object(rc *ReconcileContext) client.Object {
+	return &certmgr.Certificate{ObjectMeta: metav1.ObjectMeta{Name: UICertificateData.Name, Namespace: rc.Instance.Namespace}}
+}
+
+func (certificateResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	return getDesiredCertificate(ctx, rc.Client, rc.Instance, UICertificateData)
+}
+
+func (certificateResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
 
-		err := client.Get(ctx, types.NamespacedName{Name: certData.Name, Namespace: instance.Namespace}, certificate)
+func (certificateResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return nil
+}
 
-		if err != nil && errors.IsNotFound(err) {
-			reqLogger.Info("Creating a new certificate", "Certificate.Namespace", desiredCertificate.Namespace, "Certificate.Name", desiredCertificate.Name)
+// waitForCertificateSecret returns how long to wait before checking the certificate secret again, or 0 once it
+// exists. The reconcile is not blocked, the secret watch triggers it as soon as the secret is issued.
+// A certificate secret provided in the CR is validated, an invalid secret fails the reconcile.
+func waitForCertificateSecret(ctx context.Context, rc *ReconcileContext) (time.Duration, error) {
+	instance := rc.Instance
+	secretName := GetCertificateSecretName(instance)
+	reqLogger := log.WithValues("func", "waitForCertificateSecret", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace,
+		"SecretName", secretName)
 
-			err = client.Create(ctx, desiredCertificate)
+	certSecret := &corev1.Secret{}
+	err := rc.Reader().Get(ctx, types.NamespacedName{Name: secretName, Namespace: instance.Namespace}, certSecret)
+	if err == nil {
+		if IsCertificateProvided(instance) {
+			err = validateCertificateSecret(certSecret, getServiceDNSNames(UICertificateData.Common, instance.Namespace))
 			if err != nil {
-				if errors.IsAlreadyExists(err) {
-					// Certificate already exists from a previous reconcile
-					reqLogger.Info("Certificate already exists")
-					*needToRequeue = true
-				} else {
-					// Failed to create a new certificate
-					reqLogger.Info("Failed to create a new certificate", "Certificate.Namespace", desiredCertificate.Namespace, "Certificate.Name", desiredCertificate.Name)
-					return err
-				}
-			} else {
-				// Requeue after creating new certificate
-				*needToRequeue = true
-			}
-		} else if err != nil && !errors.IsNotFound(err) {
-			reqLogger.Error(err, "Failed to get certificate", "Certificate.Namespace", instance.Namespace, "Certificate.Name", certData.Name)
-			return err
-		} else {
-			// Determine if current certificate has changed
-			reqLogger.Info("Comparing current and desired certificates")
-
-			if !IsCertificateEqual(certificate, desiredCertificate) {
-				reqLogger.Info("Updating certificate", "Certificate.Namespace", certificate.Namespace, "Certificate.Name", certificate.Name)
-
-				certificate.ObjectMeta.Name = desiredCertificate.ObjectMeta.Name
-				certificate.ObjectMeta.Labels = desiredCertificate.ObjectMeta.Labels
-				certificate.Spec = desiredCertificate.Spec
-
-				err = client.Update(ctx, certificate)
-				if err != nil {
-					reqLogger.Error(err, "Failed to update certificate", "Certificate.Namespace", certificate.Namespace, "Certificate.Name", certificate.Name)
-					return err
-				}
+				reqLogger.Error(err, "Certificate secret provided in the CR cannot be used")
+				return 0, err
 			}
+			rc.RequeueWithin(ProvidedSecretResyncInterval)
+		}
+		reqLogger.Info("Certificate secret exists - reconcile will continue")
+		SetCondition(instance, operatorsv1beta1.ConditionWaitingForCertificate, metav1.ConditionFalse, operatorsv1beta1.ReasonCertificateReady,
+			"Certificate secret "+secretName+" exists")
+		return 0, nil
+	}
+	if !errors.IsNotFound(err) {
+		reqLogger.Error(err, "Error getting certificate secret")
+		return 0, err
+	}
+
+	reqLogger.Info("Reconcile will wait until the common-web-ui certificate secret is created")
+	message := "Waiting for certificate secret " + secretName + " to be created"
+	SetCondition(instance, operatorsv1beta1.ConditionWaitingForCertificate, metav1.ConditionTrue, operatorsv1beta1.ReasonWaitingForCertificate, message)
+	SetCondition(instance, operatorsv1beta1.ConditionProgressing, metav1.ConditionTrue, operatorsv1beta1.ReasonWaitingForCertificate, message)
+
+	timeout := rc.CertWaitTimeout
+	if timeout <= 0 {
+		timeout = DefaultCertWaitTimeout
+	}
+
+	//The wait started when the condition turned true, the transition time does not change while it stays true
+	waitingCondition := meta.FindStatusCondition(instance.Status.Conditions, operatorsv1beta1.ConditionWaitingForCertificate)
+	if time.Since(waitingCondition.LastTransitionTime.Time) > timeout {
+		timeoutErr := fmt.Errorf("certificate secret %s has not been created within %s", secretName, timeout)
+		reqLogger.Error(timeoutErr, "Timeout waiting for common-web-ui certificate secret")
+		//The warning is only recorded when the CR turns degraded, the reconcile keeps waiting for the secret
+		degradedCondition := meta.FindStatusCondition(instance.Status.Conditions, operatorsv1beta1.ConditionDegraded)
+		if rc.Recorder != nil && (degradedCondition == nil || degradedCondition.Status != metav1.ConditionTrue ||
+			degradedCondition.Reason != operatorsv1beta1.ReasonCertificateTimeout) {
+			rc.Recorder.Event(instance, corev1.EventTypeWarning, operatorsv1beta1.ReasonCertificateTimeout, timeoutErr.Error())
+		}
+		SetReconcileFailedCondition(instance, operatorsv1beta1.ReasonCertificateTimeout, timeoutErr)
+	}
+
+	return CertWaitRequeueInterval, nil
+}
+
+// getCertificateConfig returns the certificate settings of the CR, the defaults are used when they are not set
+func getCertificateConfig(instance *operatorsv1beta1.CommonWebUI) operatorsv1beta1.CertificateConfig {
+	if instance.Spec.Certificate == nil {
+		return operatorsv1beta1.CertificateConfig{}
+	}
+	return *instance.Spec.Certificate
+}
+
+func getCertificateDuration(config operatorsv1beta1.CertificateConfig) time.Duration {
+	if config.Duration != nil {
+		return config.Duration.Duration
+	}
+	return DefaultCertificateDuration
+}
+
+func getCertificateRenewBefore(config operatorsv1beta1.CertificateConfig) time.Duration {
+	if config.RenewBefore != nil {
+		return config.RenewBefore.Duration
+	}
+	return DefaultCertificateRenewBefore
+}
+
+// getCertificateDNSNames returns the DNS names of the common-web-ui service followed by the names added in the CR
+func getCertificateDNSNames(serviceName string, namespace string, config operatorsv1beta1.CertificateConfig) []string {
+	dnsNames := getServiceDNSNames(serviceName, namespace)
+	for _, dnsName := range config.DNSNames {
+		if !ContainsString(dnsNames, dnsName) {
+			dnsNames = append(dnsNames, dnsName)
+		}
+	}
+	return dnsNames
+}
+
+// getServiceDNSNames returns the DNS names of the common-web-ui service that the certificate must be valid for
+func getServiceDNSNames(serviceName string, namespace string) []string {
+	return []string{
+		serviceName,
+		serviceName + "." + namespace,
+		serviceName + "." + namespace + ".svc.cluster.local",
+	}
+}
+
+// validateCertificateSecret checks that the key matches the certificate, and that the certificate is current,
+// chains to the CA of the secret and is valid for the DNS names. The intermediate certificates can follow the
+// certificate in tls.crt.
+func validateCertificateSecret(secret *corev1.Secret, dnsNames []string) error {
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, "ca.crt"} {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf("%w: %s does not contain %s", ErrCertificateInvalid, secret.Name, key)
+		}
+	}
+
+	keyPair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrCertificateInvalid, secret.Name, err)
+	}
+	certs := make([]*x509.Certificate, 0, len(keyPair.Certificate))
+	for _, der := range keyPair.Certificate {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrCertificateInvalid, secret.Name, err)
 		}
+		certs = append(certs, cert)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(secret.Data["ca.crt"]) {
+		return fmt.Errorf("%w: %s: ca.crt does not contain a PEM certificate", ErrCertificateInvalid, secret.Name)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
 	}
 
+	//Verify checks the validity period of the chain as well
+	for _, dnsName := range dnsNames {
+		_, err = certs[0].Verify(x509.VerifyOptions{
+			DNSName:       dnsName,
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrCertificateInvalid, secret.Name, err)
+		}
+	}
 	return nil
 }
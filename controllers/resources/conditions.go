@@ -0,0 +1,124 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// SetCondition sets or updates a condition on the CR status. The last transition time
+// is only changed when the condition status changes.
+func SetCondition(instance *operatorsv1beta1.CommonWebUI, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: instance.Generation,
+	})
+}
+
+// SetReconcileFailedCondition marks the CR as degraded because a reconcile step failed
+func SetReconcileFailedCondition(instance *operatorsv1beta1.CommonWebUI, reason string, err error) {
+	message := "Reconcile failed"
+	if err != nil {
+		message = err.Error()
+	}
+	SetCondition(instance, operatorsv1beta1.ConditionDegraded, metav1.ConditionTrue, reason, message)
+	SetCondition(instance, operatorsv1beta1.ConditionReconcileSuccess, metav1.ConditionFalse, reason, message)
+}
+
+// SetReconcileSuccessCondition clears the degraded condition after all reconcile steps completed
+func SetReconcileSuccessCondition(instance *operatorsv1beta1.CommonWebUI) {
+	message := "All resources were reconciled successfully"
+	SetCondition(instance, operatorsv1beta1.ConditionDegraded, metav1.ConditionFalse, operatorsv1beta1.ReasonReconcileComplete, message)
+	SetCondition(instance, operatorsv1beta1.ConditionReconcileSuccess, metav1.ConditionTrue, operatorsv1beta1.ReasonReconcileComplete, message)
+}
+
+// SetAvailableCondition sets the available condition from the status of the managed resources
+func SetAvailableCondition(instance *operatorsv1beta1.CommonWebUI, serviceStatus operatorsv1beta1.ServiceStatus) {
+	var notReady []string
+	for _, managedResourceStatus := range serviceStatus.ManagedResources {
+		if managedResourceStatus.Status != Ready {
+			notReady = append(notReady, managedResourceStatus.Kind+"/"+managedResourceStatus.ObjectName)
+		}
+	}
+
+	if len(notReady) > 0 {
+		SetCondition(instance, operatorsv1beta1.ConditionAvailable, metav1.ConditionFalse, operatorsv1beta1.ReasonResourcesNotReady,
+			"Managed resources are not ready: "+strings.Join(notReady, ", "))
+		return
+	}
+	SetCondition(instance, operatorsv1beta1.ConditionAvailable, metav1.ConditionTrue, operatorsv1beta1.ReasonAllResourcesReady,
+		"All managed resources are ready")
+}
+
+// SetProgressingCondition sets the progressing condition from the rollout state of the common-web-ui deployment
+func SetProgressingCondition(ctx context.Context, k8sClient client.Client, instance *operatorsv1beta1.CommonWebUI) {
+	reqLogger := log.WithValues("func", "SetProgressingCondition", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	deployment := &appsv1.Deployment{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: DeploymentName, Namespace: instance.Namespace}, deployment)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			reqLogger.Error(err, "Error reading deployment for progressing condition")
+			return
+		}
+		SetCondition(instance, operatorsv1beta1.ConditionProgressing, metav1.ConditionTrue, operatorsv1beta1.ReasonDeploymentNotFound,
+			"Deployment "+DeploymentName+" has not been created yet")
+		return
+	}
+
+	if inProgress, message := isRolloutInProgress(deployment); inProgress {
+		SetCondition(instance, operatorsv1beta1.ConditionProgressing, metav1.ConditionTrue, operatorsv1beta1.ReasonRolloutInProgress, message)
+		return
+	}
+	SetCondition(instance, operatorsv1beta1.ConditionProgressing, metav1.ConditionFalse, operatorsv1beta1.ReasonRolloutComplete,
+		"Deployment "+DeploymentName+" has been rolled out")
+}
+
+func isRolloutInProgress(deployment *appsv1.Deployment) (bool, string) {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return true, "Waiting for the deployment spec update to be observed"
+	}
+	if deployment.Status.UpdatedReplicas < replicas {
+		return true, fmt.Sprintf("%d of %d replicas have been updated", deployment.Status.UpdatedReplicas, replicas)
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return true, fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return true, fmt.Sprintf("%d of %d updated replicas are available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	}
+	return false, ""
+}
@@ -18,123 +18,97 @@ package resources
 
 import (
 	"context"
-	"fmt"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const LoginConfirmationText string = "login-confirmation-text"
 const LoginConfirmationButton string = "login-confirmation-button"
 const LoginConfirmationTitle string = "login-confirmation-title"
 
-func createConfigMap(ctx context.Context, client client.Client, cm *corev1.ConfigMap, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "createConfigMap", "instance.Name", instance.Name, "configmap.Name", cm.Name)
+// log4jsConfigMapResource is the log4js configuration of the console. The data of an existing configmap
+// is kept, so the log levels can be changed in the configmap.
+type log4jsConfigMapResource struct{}
 
-	err := controllerutil.SetControllerReference(instance, cm, client.Scheme())
-	if err != nil {
-		reqLogger.Error(err, fmt.Sprintf("Failed to set owner for configmap: %s", cm.Name))
-		return err
-	}
+func (log4jsConfigMapResource) Name() string {
+	return "Log4jsConfigMap"
+}
 
-	reqLogger.Info(fmt.Sprintf("Creating configmap: %s", cm.Name))
-	err = client.Create(ctx, cm)
-	if err != nil {
-		reqLogger.Error(err, fmt.Sprintf("Failed to create configmap: %s", cm.Name))
-		return err
+func (log4jsConfigMapResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonConfigMapFailed
+}
+
+func (log4jsConfigMapResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return true
+}
+
+func (log4jsConfigMapResource) Object(rc *ReconcileContext) client.Object {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: Log4jsConfigMapName, Namespace: rc.Instance.Namespace}}
+}
+
+func (log4jsConfigMapResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Log4jsConfigMapName,
+			Namespace: rc.Instance.Namespace,
+			Labels:    LabelsForMetadata(Log4jsConfigMapName),
+		},
+		Data: Log4jsConfigMapData,
 	}
+	return cm, setControllerReference(rc, cm)
+}
 
-	// Created log4js configmap successfully, requiring a requeue
-	*needToRequeue = true
+func (log4jsConfigMapResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	if existing != nil {
+		desired.(*corev1.ConfigMap).Data = existing.(*corev1.ConfigMap).Data
+	}
+	return false
+}
 
+func (log4jsConfigMapResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
 	return nil
 }
 
-func ReconcileLog4jsConfigMap(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileLog4jsConfigMap", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling log4js configmap")
+// commonWebUIConfigMapResource is the common-web-ui-config configmap. Only the login confirmation fields
+// are applied, any other keys added to the configmap are left alone.
+type commonWebUIConfigMapResource struct{}
 
-	cm := &corev1.ConfigMap{}
+func (commonWebUIConfigMapResource) Name() string {
+	return "CommonWebUIConfigMap"
+}
 
-	// Check if the log4js configmap already exists, if not create a new one
-	err := client.Get(ctx, types.NamespacedName{Name: Log4jsConfigMapName, Namespace: instance.Namespace}, cm)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			metaLabels := LabelsForMetadata(Log4jsConfigMapName)
-			cm = &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      Log4jsConfigMapName,
-					Namespace: instance.Namespace,
-					Labels:    metaLabels,
-				},
-				Data: Log4jsConfigMapData,
-			}
-
-			err = createConfigMap(ctx, client, cm, instance, needToRequeue)
-			if err != nil {
-				return err
-			}
-		} else {
-			reqLogger.Error(err, "Failed to get log4js configmap")
-			return err
-		}
-	}
+func (commonWebUIConfigMapResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonConfigMapFailed
+}
 
-	return nil
+func (commonWebUIConfigMapResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return true
 }
 
-func ReconcileCommonUIConfigConfigMap(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileCommonUiConfigConfigMap", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling common-web-ui-config configmap")
+func (commonWebUIConfigMapResource) Object(rc *ReconcileContext) client.Object {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: CommonConfigMapName, Namespace: rc.Instance.Namespace}}
+}
 
-	cm := &corev1.ConfigMap{}
+func (commonWebUIConfigMapResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	cm := getDesiredCommonWebUIConfigmap(rc.Instance)
+	return cm, setControllerReference(rc, cm)
+}
 
-	// Check if the log4js configmap already exists, if not create a new one
-	err := client.Get(ctx, types.NamespacedName{Name: CommonConfigMapName, Namespace: instance.Namespace}, cm)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			cm := getDesiredCommonWebUIConfigmap(instance)
-			err = createConfigMap(ctx, client, cm, instance, needToRequeue)
-			if err != nil {
-				return err
-			}
-		} else {
-			reqLogger.Error(err, "Failed to get common-web-ui-config configmap")
-			return err
-		}
-	} else {
-		//Reconcile the configmap ... today we are only reconciling the login confirmation fields
-		dcm := getDesiredCommonWebUIConfigmap(instance)
-		if cm.Data[LoginConfirmationText] != dcm.Data[LoginConfirmationText] ||
-			cm.Data[LoginConfirmationButton] != dcm.Data[LoginConfirmationButton] ||
-			cm.Data[LoginConfirmationTitle] != dcm.Data[LoginConfirmationTitle] {
-
-			reqLogger.Info("LoginConfirmation not equal", "old", cm.Data, "new", dcm.Data)
-			if cm.Data == nil {
-				cm.Data = map[string]string{}
-			}
-			cm.Data[LoginConfirmationText] = dcm.Data[LoginConfirmationText]
-			cm.Data[LoginConfirmationButton] = dcm.Data[LoginConfirmationButton]
-			cm.Data[LoginConfirmationTitle] = dcm.Data[LoginConfirmationTitle]
-
-			err = client.Update(ctx, cm)
-			if err != nil {
-				reqLogger.Error(err, "Failed to update configmap", "Name", cm.Name)
-				return err
-			}
-		}
-	}
+func (commonWebUIConfigMapResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
 
+func (commonWebUIConfigMapResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
 	return nil
 }
 
-func getDesiredCommonWebUIConfigmap(instance *operatorsv1alpha1.CommonWebUI) *corev1.ConfigMap {
+func getDesiredCommonWebUIConfigmap(instance *operatorsv1beta1.CommonWebUI) *corev1.ConfigMap {
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      CommonConfigMapName,
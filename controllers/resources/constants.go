@@ -17,6 +17,8 @@
 package resources
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -47,6 +49,15 @@ const DaemonSetName = "common-web-ui"
 const CertRestartLabel = "certmanager.k8s.io/time-restarted"
 const NSSAnnotation = "nss.ibm.com/namespaceList"
 
+// PausedAnnotation set to "true" on the CR stops the reconcile of all managed resources
+const PausedAnnotation = "commonui.operators.ibm.com/paused"
+
+// UnmanagedAnnotation set to "true" on a managed object leaves the object to manual control
+const UnmanagedAnnotation = "commonui.operators.ibm.com/unmanaged"
+
+// CertificateSerialAnnotation on the pod template restarts the pods when the self-signed certificate is rotated
+const CertificateSerialAnnotation = "commonui.operators.ibm.com/certificate-serial"
+
 const DefaultNamespace = "ibm-common-services"
 const DefaultImageRegistry = "icr.io/cpopen/cpfs"
 const DefaultImageName = "common-web-ui"
@@ -58,6 +69,16 @@ const Log4jsConfigMapName = "common-web-ui-log4js"
 
 const HPAName = "common-web-ui-hpa"
 
+const PDBName = "common-web-ui-pdb"
+
+const NetworkPolicyName = "common-web-ui-network-policy"
+
+// Namespace of the ingress controller on CNCF clusters when it is not set in the CR
+const DefaultIngressControllerNamespace = "ingress-nginx"
+
+// The OpenShift router namespaces carry this label, it also covers routers that use the host network
+const OpenShiftIngressPolicyGroupLabel = "network.openshift.io/policy-group"
+
 var Log4jsConfigMapData = map[string]string{
 	"log4js.json": `   {
 		"appenders": {
@@ -109,6 +130,10 @@ const WebUIConfigVolumeName = "common-web-ui-config"
 const ClusterInfoConfigVolumeName = "ibmcloud-cluster-info"
 const PlatformAuthIdpConfigVolumeName = "platform-auth-idp"
 const ZenProductInfoConfigVolumeName = "product-configmap"
+const SwitcherConfigVolumeName = "common-web-ui-switcher"
+
+// SwitcherConfigPath is where common-web-ui reads the product switcher items published by the SwitcherItem controller
+const SwitcherConfigPath = "/etc/config/common-web-ui-switcher/" + SwitcherConfigMapKey
 
 var Log4jsVolume = corev1.Volume{
 	Name: Log4jsVolumeName,
@@ -181,6 +206,19 @@ var ZenProductInfoConfigVolume = corev1.Volume{
 	},
 }
 
+var SwitcherConfigVolume = corev1.Volume{
+	Name: SwitcherConfigVolumeName,
+	VolumeSource: corev1.VolumeSource{
+		ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: SwitcherConfigMapName,
+			},
+			Optional:    &TrueVar,
+			DefaultMode: &DefaultVolumeMode,
+		},
+	},
+}
+
 var ClusterCaVolume = corev1.Volume{
 	Name: ClusterCaVolumeName,
 	VolumeSource: corev1.VolumeSource{
@@ -327,42 +365,52 @@ var CommonVolumeMounts = []corev1.VolumeMount{
 		Name:      ZenProductInfoConfigVolumeName,
 		MountPath: "/etc/config/product-configmap",
 	},
+	{
+		Name:      SwitcherConfigVolumeName,
+		MountPath: "/etc/config/common-web-ui-switcher",
+	},
 }
 
+// Names of the ingresses of cloudpak 2.0, they are removed if they are found
 const APIIngressName = "common-web-ui-api"
-
-var APIIngressAnnotations = map[string]string{
-	"kubernetes.io/ingress.class":            "ibm-icp-management",
-	"icp.management.ibm.com/secure-backends": "true",
-	//nolint
-	"icp.management.ibm.com/configuration-snippet": `
-		add_header 'X-XSS-Protection' '1' always;
-        port_in_redirect off;`,
-}
-
 const CallbackIngressName = "common-web-ui-callback"
-
-var CallbackIngressAnnotations = map[string]string{
-	"kubernetes.io/ingress.class":            "ibm-icp-management",
-	"icp.management.ibm.com/upstream-uri":    "/auth/liberty/callback",
-	"icp.management.ibm.com/secure-backends": "true",
-}
-
 const NavIngressName = "common-web-ui"
 
-var NavIngressAnnotations = map[string]string{
-	"kubernetes.io/ingress.class":            "ibm-icp-management",
-	"icp.management.ibm.com/auth-type":       "access-token",
-	"icp.management.ibm.com/secure-backends": "true",
-	"icp.management.ibm.com/app-root":        "/common-nav?root=true",
-	//nolint
-	"icp.management.ibm.com/configuration-snippet": `
-		add_header 'X-XSS-Protection' '1' always;`,
+// CnIngressName is the Ingress that exposes the console on CNCF clusters
+const CnIngressName = "cp-console"
+
+// CnIngressAnnotations make the common ingress controllers connect to the common-web-ui service over TLS,
+// the annotations in the CR are added to them
+var CnIngressAnnotations = map[string]string{
+	"nginx.ingress.kubernetes.io/backend-protocol":   "HTTPS",
+	"nginx.ingress.kubernetes.io/proxy-read-timeout": "90",
+	"haproxy.org/server-ssl":                         "true",
+	"haproxy-ingress.github.io/backend-protocol":     "h1-ssl",
+	"alb.ingress.kubernetes.io/backend-protocol":     "HTTPS",
 }
 
 const DefaultClusterIssuer = "cs-ca-issuer"
 const Certv1alpha1APIVersion = "certmanager.k8s.io/v1alpha1"
 const UICertName = "common-web-ui-ca-cert"
+
+// How often the certificate secret is checked while it has not been issued, the secret watch usually
+// triggers the reconcile earlier. The timeout can be changed with the CERT_WAIT_TIMEOUT env var.
+const CertWaitRequeueInterval = 10 * time.Second
+const DefaultCertWaitTimeout = 5 * time.Minute
+
+// How often the secrets provided in the CR are read again, they are not in the cache of the operator so a
+// rotated secret is only noticed by the next reconcile
+const ProvidedSecretResyncInterval = 10 * time.Minute
+
+// Lifetime of the common-web-ui certificate when it is not set in the CR
+const DefaultCertificateDuration = 9552 * time.Hour    /* 398 days */
+const DefaultCertificateRenewBefore = 2880 * time.Hour /* 120 days (3 months) */
+
+// The CA that issues the common-web-ui certificate when neither cert-manager nor a certificate secret in
+// the CR are available
+const SelfSignedCASecretName = "common-web-ui-ca"
+const SelfSignedCADuration = 5 * 365 * 24 * time.Hour
+const SelfSignedCARenewBefore = 365 * 24 * time.Hour
 const UICertCommonName = "common-web-ui"
 
 // type CertificateData struct {
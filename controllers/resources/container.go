@@ -236,3 +236,48 @@ var CommonContainer = corev1.Container{
 		},
 	},
 }
+
+// EnvBuilder sets container environment variables by name. Variables keep the position they have in
+// the base list and new variables are appended, so the order of CommonContainer.Env does not matter.
+type EnvBuilder struct {
+	env   []corev1.EnvVar
+	index map[string]int
+}
+
+// NewEnvBuilder returns a builder that starts from a copy of the given variables
+func NewEnvBuilder(base []corev1.EnvVar) *EnvBuilder {
+	builder := &EnvBuilder{index: map[string]int{}}
+	for _, envVar := range base {
+		builder.SetVar(*envVar.DeepCopy())
+	}
+	return builder
+}
+
+// Set sets the variable to a plain value, replacing a valueFrom source if there was one
+func (b *EnvBuilder) Set(name, value string) *EnvBuilder {
+	return b.SetVar(corev1.EnvVar{Name: name, Value: value})
+}
+
+// SetVar replaces the variable with the same name or appends it
+func (b *EnvBuilder) SetVar(envVar corev1.EnvVar) *EnvBuilder {
+	if i, found := b.index[envVar.Name]; found {
+		b.env[i] = envVar
+		return b
+	}
+	b.index[envVar.Name] = len(b.env)
+	b.env = append(b.env, envVar)
+	return b
+}
+
+// Merge sets all given variables, they take precedence over the variables already set
+func (b *EnvBuilder) Merge(env []corev1.EnvVar) *EnvBuilder {
+	for _, envVar := range env {
+		b.SetVar(*envVar.DeepCopy())
+	}
+	return b
+}
+
+// Build returns the variables
+func (b *EnvBuilder) Build() []corev1.EnvVar {
+	return b.env
+}
@@ -0,0 +1,148 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+func TestEnvBuilder(t *testing.T) {
+	secretRef := &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "platform-oidc-credentials"},
+		Key:                  "WLP_CLIENT_ID",
+	}}
+
+	tests := []struct {
+		name  string
+		base  []corev1.EnvVar
+		set   map[string]string
+		merge []corev1.EnvVar
+		want  []corev1.EnvVar
+	}{
+		{
+			name: "set keeps the position of a base variable",
+			base: []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}},
+			set:  map[string]string{"A": "3"},
+			want: []corev1.EnvVar{{Name: "A", Value: "3"}, {Name: "B", Value: "2"}},
+		},
+		{
+			name: "new variables are appended",
+			base: []corev1.EnvVar{{Name: "A", Value: "1"}},
+			set:  map[string]string{"B": "2"},
+			want: []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}},
+		},
+		{
+			name:  "merged variables override the operator defaults",
+			base:  []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}},
+			set:   map[string]string{"B": "operator"},
+			merge: []corev1.EnvVar{{Name: "B", Value: "user"}, {Name: "C", Value: "3"}},
+			want:  []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "user"}, {Name: "C", Value: "3"}},
+		},
+		{
+			name:  "the last duplicate wins",
+			merge: []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "A", Value: "2"}},
+			want:  []corev1.EnvVar{{Name: "A", Value: "2"}},
+		},
+		{
+			name: "duplicates in the base are collapsed",
+			base: []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}, {Name: "A", Value: "3"}},
+			want: []corev1.EnvVar{{Name: "A", Value: "3"}, {Name: "B", Value: "2"}},
+		},
+		{
+			name:  "valueFrom replaces a value",
+			base:  []corev1.EnvVar{{Name: "A", Value: "1"}},
+			merge: []corev1.EnvVar{{Name: "A", ValueFrom: secretRef}},
+			want:  []corev1.EnvVar{{Name: "A", ValueFrom: secretRef}},
+		},
+		{
+			name: "value replaces a valueFrom",
+			base: []corev1.EnvVar{{Name: "A", ValueFrom: secretRef}},
+			set:  map[string]string{"A": "1"},
+			want: []corev1.EnvVar{{Name: "A", Value: "1"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder := NewEnvBuilder(test.base)
+			for name, value := range test.set {
+				builder.Set(name, value)
+			}
+			got := builder.Merge(test.merge).Build()
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestEnvBuilderCopiesTheBase(t *testing.T) {
+	base := []corev1.EnvVar{{Name: "A", Value: "1"}}
+	NewEnvBuilder(base).Set("A", "2")
+	if base[0].Value != "1" {
+		t.Errorf("expected the base variables to be left unchanged, got %+v", base)
+	}
+}
+
+func TestDeploymentEnv(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorsv1beta1.AddToScheme(scheme)
+
+	instance := &operatorsv1beta1.CommonWebUI{}
+	instance.Name = "example-commonwebui"
+	instance.Namespace = "cs"
+	instance.Spec.CommonWebUIConfig.LandingPage = "/common-nav/dashboard"
+	instance.Spec.CommonWebUIConfig.Env = []corev1.EnvVar{
+		{Name: "LANDING_PAGE", Value: "/common-nav/overview"},
+		{Name: "CUSTOM", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "custom"},
+			Key:                  "value",
+		}}},
+	}
+
+	deployment, err := getDesiredDeployment(context.Background(), fake.NewClientBuilder().WithScheme(scheme).Build(), instance, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]corev1.EnvVar{}
+	for _, envVar := range deployment.Spec.Template.Spec.Containers[0].Env {
+		if _, found := env[envVar.Name]; found {
+			t.Errorf("expected %s once in the container env", envVar.Name)
+		}
+		env[envVar.Name] = envVar
+	}
+	if env["LANDING_PAGE"].Value != "/common-nav/overview" {
+		t.Errorf("expected spec.commonWebUIConfig.env to override the landing page, got %q", env["LANDING_PAGE"].Value)
+	}
+	if env["CUSTOM"].ValueFrom == nil || env["CUSTOM"].ValueFrom.ConfigMapKeyRef == nil {
+		t.Errorf("expected the valueFrom of CUSTOM to be kept, got %+v", env["CUSTOM"])
+	}
+	if env["CLUSTER_TYPE"].Value != "cncf" {
+		t.Errorf("expected the operator variables to be kept, got %+v", env["CLUSTER_TYPE"])
+	}
+}
@@ -0,0 +1,72 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// Defaults for the common-web-ui deployment when they are not set in the CR. The resource defaults are
+// in operatorsv1beta1.DefaultResources, so the webhook validates against the same values.
+const DefaultReplicas int32 = 1
+
+// GetEffectiveSpec returns the deployment configuration of the CR with the defaults filled in. The
+// defaults are not written to the spec, so an operator upgrade can still change them.
+func GetEffectiveSpec(instance *operatorsv1beta1.CommonWebUI) operatorsv1beta1.EffectiveSpec {
+	replicas := instance.Spec.Replicas
+	if replicas == 0 {
+		replicas = DefaultReplicas
+	}
+
+	imageRegistry := GetStringWithDefault(instance.Spec.CommonWebUIConfig.ImageRegistry, DefaultImageRegistry)
+	imageTag := GetStringWithDefault(instance.Spec.CommonWebUIConfig.ImageTag, DefaultImageTag)
+
+	return operatorsv1beta1.EffectiveSpec{
+		Replicas:      replicas,
+		ImageRegistry: imageRegistry,
+		ImageTag:      imageTag,
+		Image:         GetImageID(imageRegistry, DefaultImageName, imageTag, "", "RELATED_IMAGE_COMMON_WEB_UI_IMAGE"),
+		Resources:     getEffectiveResources(instance),
+		AutoScaling:   GetEffectiveAutoScaling(instance),
+	}
+}
+
+// getEffectiveResources returns the cpu, memory and ephemeral-storage of the CR, with the defaults for the
+// values that are not set. The quantities of the CR are kept as written.
+func getEffectiveResources(instance *operatorsv1beta1.CommonWebUI) corev1.ResourceRequirements {
+	resources := operatorsv1beta1.DefaultResources()
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+		if quantity, found := instance.Spec.Resources.Limits[name]; found {
+			resources.Limits[name] = quantity.DeepCopy()
+		}
+		if quantity, found := instance.Spec.Resources.Requests[name]; found {
+			resources.Requests[name] = quantity.DeepCopy()
+			continue
+		}
+		//A default request must not be above a lower limit of the CR, the deployment would be rejected
+		if limit, found := instance.Spec.Resources.Limits[name]; found {
+			if request := resources.Requests[name]; request.Cmp(limit) > 0 {
+				resources.Requests[name] = limit.DeepCopy()
+			}
+		}
+	}
+
+	return resources
+}
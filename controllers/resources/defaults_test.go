@@ -0,0 +1,81 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+func TestGetEffectiveResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources corev1.ResourceRequirements
+		requests  corev1.ResourceList
+		limits    corev1.ResourceList
+	}{
+		{
+			name:     "defaults",
+			requests: corev1.ResourceList{"cpu": resource.MustParse("300m"), "memory": resource.MustParse("512Mi"), "ephemeral-storage": resource.MustParse("251Mi")},
+			limits:   corev1.ResourceList{"cpu": resource.MustParse("1000m"), "memory": resource.MustParse("512Mi")},
+		},
+		{
+			name: "quantities are kept as written",
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{"cpu": resource.MustParse("0.5"), "memory": resource.MustParse("1G")},
+				Limits:   corev1.ResourceList{"memory": resource.MustParse("2G"), "ephemeral-storage": resource.MustParse("1Gi")},
+			},
+			requests: corev1.ResourceList{"cpu": resource.MustParse("0.5"), "memory": resource.MustParse("1G"), "ephemeral-storage": resource.MustParse("251Mi")},
+			limits:   corev1.ResourceList{"cpu": resource.MustParse("1000m"), "memory": resource.MustParse("2G"), "ephemeral-storage": resource.MustParse("1Gi")},
+		},
+		{
+			name: "default request is lowered to the limit",
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{"memory": resource.MustParse("256Mi")},
+			},
+			requests: corev1.ResourceList{"cpu": resource.MustParse("300m"), "memory": resource.MustParse("256Mi"), "ephemeral-storage": resource.MustParse("251Mi")},
+			limits:   corev1.ResourceList{"cpu": resource.MustParse("1000m"), "memory": resource.MustParse("256Mi")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := &operatorsv1beta1.CommonWebUI{}
+			instance.Spec.Resources = test.resources
+
+			resources := getEffectiveResources(instance)
+			for _, list := range []struct {
+				name             string
+				actual, expected corev1.ResourceList
+			}{{"requests", resources.Requests, test.requests}, {"limits", resources.Limits, test.limits}} {
+				if len(list.actual) != len(list.expected) {
+					t.Errorf("expected %s %v, got %v", list.name, list.expected, list.actual)
+				}
+				for name, expected := range list.expected {
+					// String compares the written form, so 1G must not come back as 954Mi
+					if actual := list.actual[name]; actual.String() != expected.String() {
+						t.Errorf("expected %s %s of %s, got %s", list.name, name, expected.String(), actual.String())
+					}
+				}
+			}
+		})
+	}
+}
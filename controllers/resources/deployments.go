@@ -18,24 +18,24 @@ package resources
 
 import (
 	"context"
+	errorf "errors"
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 )
 
 // nolint
-func getDesiredDeployment(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, isZen bool, isCncf bool) (*appsv1.Deployment, error) {
+func getDesiredDeployment(ctx context.Context, client client.Client, instance *operatorsv1beta1.CommonWebUI, isZen bool, isCncf bool) (*appsv1.Deployment, error) {
 	reqLogger := log.WithValues("func", "getDesiredDeployment", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 
 	volumes := []corev1.Volume{}
@@ -47,63 +47,52 @@ func getDesiredDeployment(ctx context.Context, client client.Client, instance *o
 
 	var err error
 
-	replicas := instance.Spec.Replicas
-	if replicas == 0 {
-		replicas = 1
-	}
-
-	cpuLimits := GetResourceLimitsWithDefault(instance.Spec.Resources.Limits.CPULimits, 1000)
-	cpuMemory := GetResourceMemoryWithDefault(instance.Spec.Resources.Limits.CPUMemory, 512)
-	limEphemeral := GetResourceMemoryWithDefault(instance.Spec.Resources.Limits.EphemeralStorage, -1)
-	reqLimits := GetResourceLimitsWithDefault(instance.Spec.Resources.Requests.RequestLimits, 300)
-	reqMemory := GetResourceMemoryWithDefault(instance.Spec.Resources.Requests.RequestMemory, 512)
-	reqEphemeral := GetResourceMemoryWithDefault(instance.Spec.Resources.Requests.EphemeralStorage, 251)
-
-	imageRegistry := GetStringWithDefault(instance.Spec.CommonWebUIConfig.ImageRegistry, DefaultImageRegistry)
-	imageTag := GetStringWithDefault(instance.Spec.CommonWebUIConfig.ImageTag, DefaultImageTag)
-	image := GetImageID(imageRegistry, DefaultImageName, imageTag, "", "RELATED_IMAGE_COMMON_WEB_UI_IMAGE")
+	effectiveSpec := GetEffectiveSpec(instance)
+	replicas := effectiveSpec.Replicas
+	image := effectiveSpec.Image
 
 	reqLogger.Info(fmt.Sprintf("Current image ID: %s", image))
 
-	volumes = append(volumes, Log4jsVolume, ClusterCaVolume, UICertVolume, InternalTLSVolume, IAMDataVolume, IAMAuthDataVolume,
-		WebUIConfigVolume, ClusterInfoConfigVolume, PlatformAuthIdpConfigVolume, ZenProductInfoConfigVolume)
+	//The certificate secret can be provided in the CR instead of being issued by cert-manager
+	certVolume := *UICertVolume.DeepCopy()
+	certVolume.Secret.SecretName = GetCertificateSecretName(instance)
+
+	volumes = append(volumes, Log4jsVolume, ClusterCaVolume, certVolume, InternalTLSVolume, IAMDataVolume, IAMAuthDataVolume,
+		WebUIConfigVolume, ClusterInfoConfigVolume, PlatformAuthIdpConfigVolume, ZenProductInfoConfigVolume, SwitcherConfigVolume)
 
 	container := *CommonContainer.DeepCopy()
 	container.Image = image
 	container.Name = DeploymentName
-	container.Env[6].Value = instance.Spec.GlobalUIConfig.CloudPakVersion
-	container.Env[8].Value = instance.Spec.GlobalUIConfig.DefaultAuth
-	container.Env[9].Value = instance.Spec.GlobalUIConfig.EnterpriseLDAP
-	container.Env[10].Value = instance.Spec.GlobalUIConfig.EnterpriseSAML
-	container.Env[11].Value = instance.Spec.GlobalUIConfig.OSAuth
-	container.Env[19].Value = instance.Spec.CommonWebUIConfig.LandingPage
-	container.Env[20].Value = os.Getenv("WATCH_NAMESPACE")
-	container.Env[27].Value = strconv.FormatBool(instance.Spec.EnableInstanaMetricCollection)
-
-	container.Resources.Limits["cpu"] = *resource.NewMilliQuantity(cpuLimits, resource.DecimalSI)
-	container.Resources.Limits["memory"] = *resource.NewQuantity(cpuMemory*1024*1024, resource.BinarySI)
-	container.Resources.Requests["cpu"] = *resource.NewMilliQuantity(reqLimits, resource.DecimalSI)
-	container.Resources.Requests["memory"] = *resource.NewQuantity(reqMemory*1024*1024, resource.BinarySI)
-	container.Resources.Requests["ephemeral-storage"] = *resource.NewQuantity(reqEphemeral*1024*1024, resource.BinarySI)
-	//ephemeral-storage limit has no default, so only set it when it appears in the CR
-	if limEphemeral > 0 {
-		container.Resources.Limits["ephemeral-storage"] = *resource.NewQuantity(limEphemeral*1024*1024, resource.BinarySI)
-	}
+	env := NewEnvBuilder(container.Env).
+		Set("CLOUDPAK_VERSION", instance.Spec.GlobalUIConfig.CloudPakVersion).
+		Set("defaultAuth", instance.Spec.GlobalUIConfig.DefaultAuth).
+		Set("enterpriseLDAP", instance.Spec.GlobalUIConfig.EnterpriseLDAP).
+		Set("enterpriseSAML", instance.Spec.GlobalUIConfig.EnterpriseSAML).
+		Set("osAuth", instance.Spec.GlobalUIConfig.OSAuth).
+		Set("LANDING_PAGE", instance.Spec.CommonWebUIConfig.LandingPage).
+		Set("WATCH_NAMESPACE", os.Getenv("WATCH_NAMESPACE")).
+		Set("INSTANA_AGENT_ENABLED", strconv.FormatBool(instance.Spec.EnableInstanaMetricCollection)).
+		Set("SWITCHER_CONFIG_PATH", SwitcherConfigPath)
+
+	container.Resources = *effectiveSpec.Resources.DeepCopy()
 	container.VolumeMounts = CommonVolumeMounts
 
 	if isZen {
 		reqLogger.Info("Setting use zen to true in container def")
-		container.Env[22].Value = "true"
+		env.Set("USE_ZEN", "true")
 	} else {
 		reqLogger.Info("Setting use zen to false in container def")
-		container.Env[22].Value = "false"
+		env.Set("USE_ZEN", "false")
 	}
 
 	if isCncf {
 		reqLogger.Info("Setting cluster type env var to cncf")
-		container.Env[24].Value = "cncf"
+		env.Set("CLUSTER_TYPE", "cncf")
 	}
 
+	// Variables from the CR are applied last so they can override the ones set by the operator
+	container.Env = env.Merge(instance.Spec.CommonWebUIConfig.Env).Build()
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      DeploymentName,
@@ -118,7 +107,7 @@ func getDesiredDeployment(ctx context.Context, client client.Client, instance *o
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      podLabels,
-					Annotations: DeploymentAnnotations,
+					Annotations: MergeMap(map[string]string{}, DeploymentAnnotations),
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName:            "ibm-commonui-operand",
@@ -229,6 +218,8 @@ func getDesiredDeployment(ctx context.Context, client client.Client, instance *o
 		},
 	}
 
+	applyScheduling(&deployment.Spec.Template.Spec, instance.Spec.Scheduling)
+
 	//If an image pull secret is set in the ENV, then set it into the pod spec
 	ips, ipsExists := os.LookupEnv("IMAGE_PULL_SECRET")
 	if ipsExists {
@@ -247,78 +238,134 @@ func getDesiredDeployment(ctx context.Context, client client.Client, instance *o
 	return deployment, nil
 }
 
-// nolint
-func ReconcileDeployment(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, isZen bool, isCncf bool, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileDeployment", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling deployment")
-
-	deployment := &appsv1.Deployment{}
+// applyScheduling merges the scheduling settings of the CR into the default pod placement
+func applyScheduling(podSpec *corev1.PodSpec, scheduling *operatorsv1beta1.Scheduling) {
+	if scheduling == nil {
+		return
+	}
 
-	desiredDeployment, desiredErr := getDesiredDeployment(ctx, client, instance, isZen, isCncf)
-	if desiredErr != nil {
-		return desiredErr
+	if len(scheduling.NodeSelector) > 0 {
+		podSpec.NodeSelector = MergeMap(podSpec.NodeSelector, scheduling.NodeSelector)
 	}
 
-	err := client.Get(ctx, types.NamespacedName{Name: DeploymentName, Namespace: instance.Namespace}, deployment)
+	podSpec.PriorityClassName = GetStringWithDefault(scheduling.PriorityClassName, podSpec.PriorityClassName)
 
-	if err != nil && errors.IsNotFound(err) {
-		reqLogger.Info("Creating a new deployment", "Deployment.Namespace", desiredDeployment.Namespace, "Deployment.Name", desiredDeployment.Name)
+	for _, toleration := range scheduling.Tolerations {
+		replaced := false
+		for i := range podSpec.Tolerations {
+			if podSpec.Tolerations[i].Key == toleration.Key && podSpec.Tolerations[i].Effect == toleration.Effect {
+				podSpec.Tolerations[i] = *toleration.DeepCopy()
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			podSpec.Tolerations = append(podSpec.Tolerations, *toleration.DeepCopy())
+		}
+	}
 
-		err = client.Create(ctx, desiredDeployment)
-		if err != nil {
-			if errors.IsAlreadyExists(err) {
-				// Deployment already exists from a previous reconcile
-				reqLogger.Info("Deployment already exists")
-				*needToRequeue = true
-			} else {
-				// Failed to create a new deployment
-				reqLogger.Info("Failed to create a new deployment", "Deployment.Namespace", desiredDeployment.Namespace, "Deployment.Name", desiredDeployment.Name)
-				return err
+	for _, constraint := range scheduling.TopologySpreadConstraints {
+		replaced := false
+		for i := range podSpec.TopologySpreadConstraints {
+			if podSpec.TopologySpreadConstraints[i].TopologyKey == constraint.TopologyKey {
+				podSpec.TopologySpreadConstraints[i] = *constraint.DeepCopy()
+				replaced = true
+				break
 			}
-		} else {
-			// Requeue after creating new deployment
-			*needToRequeue = true
 		}
-	} else if err != nil && !errors.IsNotFound(err) {
-		reqLogger.Error(err, "Failed to get deployment", "Deployment.Namespace", instance.Namespace, "Deployment.Name", DeploymentName)
-		return err
-	} else {
-		// Determine if current deployment has changed
-		reqLogger.Info("Comparing current and desired deployments")
+		if !replaced {
+			podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, *constraint.DeepCopy())
+		}
+	}
 
-		// Preserve annotations added by NamespaceScope Operator
-		PreserveKeyValue(NSSAnnotation, deployment.Spec.Template.ObjectMeta.Annotations, desiredDeployment.Spec.Template.ObjectMeta.Annotations)
+	if scheduling.Affinity != nil {
+		if podSpec.Affinity == nil {
+			podSpec.Affinity = &corev1.Affinity{}
+		}
+		if scheduling.Affinity.NodeAffinity != nil {
+			podSpec.Affinity.NodeAffinity = scheduling.Affinity.NodeAffinity.DeepCopy()
+		}
+		if scheduling.Affinity.PodAffinity != nil {
+			podSpec.Affinity.PodAffinity = scheduling.Affinity.PodAffinity.DeepCopy()
+		}
+		if scheduling.Affinity.PodAntiAffinity != nil {
+			podSpec.Affinity.PodAntiAffinity = scheduling.Affinity.PodAntiAffinity.DeepCopy()
+		}
+	}
+}
 
-		// Preserve labels added by cert-manager
-		PreserveKeyValue(CertRestartLabel, deployment.ObjectMeta.Labels, desiredDeployment.ObjectMeta.Labels)
-		PreserveKeyValue(CertRestartLabel, deployment.Spec.Template.ObjectMeta.Labels, desiredDeployment.Spec.Template.ObjectMeta.Labels)
+// deploymentResource is the deployment of the common-web-ui pods. It waits for the certificate secret,
+// otherwise the deployment gets updated multiple times in rapid succession which can mess up zone spreading
+// https://github.ibm.com/IBMPrivateCloud/roadmap/issues/63642
+type deploymentResource struct{}
 
-		//If autoscaling is enabled, do not reconcile replicas
-		if instance.Spec.AutoScaleConfig {
-			desiredDeployment.Spec.Replicas = deployment.Spec.Replicas
-			reqLogger.Info("HPA enabled, not reconciling replicas", "current replicas", deployment.Spec.Replicas)
-		}
+func (deploymentResource) Name() string {
+	return "Deployment"
+}
 
-		if !IsDeploymentEqual(deployment, desiredDeployment) {
-			reqLogger.Info("Updating deployment", "Deployment.Namespace", desiredDeployment.Namespace, "Deployment.Name", desiredDeployment.Name)
+func (deploymentResource) FailedReason(err error) string {
+	if errorf.Is(err, ErrCertificateInvalid) {
+		return operatorsv1beta1.ReasonCertificateInvalid
+	}
+	return operatorsv1beta1.ReasonDeploymentFailed
+}
 
-			deployment.ObjectMeta.Name = desiredDeployment.ObjectMeta.Name
-			deployment.ObjectMeta.Labels = desiredDeployment.ObjectMeta.Labels
-			currentReplicas := *deployment.Spec.Replicas
-			deployment.Spec = desiredDeployment.Spec
+func (deploymentResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return true
+}
 
-			if currentReplicas == 0 {
-				// Since current deployment has been scaled to 0 replicas, do not use the default replica count in the new deployment.
-				deployment.Spec.Replicas = &currentReplicas
-			}
+func (deploymentResource) Object(rc *ReconcileContext) client.Object {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: DeploymentName, Namespace: rc.Instance.Namespace}}
+}
 
-			err = client.Update(ctx, deployment)
-			if err != nil {
-				reqLogger.Error(err, "Failed to update deployment", "Deployment.Namespace", desiredDeployment.Namespace, "Deployment.Name", desiredDeployment.Name)
-				return err
-			}
+func (deploymentResource) WaitFor(ctx context.Context, rc *ReconcileContext) (time.Duration, error) {
+	return waitForCertificateSecret(ctx, rc)
+}
+
+func (deploymentResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	deployment, err := getDesiredDeployment(ctx, rc.Client, rc.Instance, rc.IsZen, rc.IsCncf)
+	if err != nil {
+		return nil, err
+	}
+
+	//cert-manager restarts the pods when it renews the certificate, the operator does it for the certificate
+	//it issues itself
+	if IsSelfSignedCertificate(rc) {
+		serial, err := getSelfSignedCertificateSerial(ctx, rc)
+		if err != nil {
+			return nil, err
 		}
+		if deployment.Spec.Template.Annotations == nil {
+			deployment.Spec.Template.Annotations = map[string]string{}
+		}
+		deployment.Spec.Template.Annotations[CertificateSerialAnnotation] = serial
 	}
+	return deployment, nil
+}
 
-	return nil
+// Mutate keeps the replicas that are managed outside of the CR. The annotations added by the NamespaceScope
+// operator and the labels added by cert-manager are not part of the desired deployment, so the apply leaves
+// them alone.
+func (deploymentResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	if existing == nil {
+		return false
+	}
+	deployment := existing.(*appsv1.Deployment)
+	desiredDeployment := desired.(*appsv1.Deployment)
+
+	if rc.Instance.Spec.GetAutoScaling().IsEnabled() {
+		//If autoscaling is enabled, the replicas are set by the HPA. The apply keeps owning spec.replicas, so
+		//leaving it out would reset it to the default, the live count is applied instead.
+		desiredDeployment.Spec.Replicas = deployment.Spec.Replicas
+	} else if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		// Since current deployment has been scaled to 0 replicas, do not use the default replica count in the new deployment.
+		desiredDeployment.Spec.Replicas = deployment.Spec.Replicas
+	}
+	return false
+}
+
+func (deploymentResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return []operatorsv1beta1.ManagedResourceStatus{
+		getDeploymentStatus(ctx, rc.Client, types.NamespacedName{Name: DeploymentName, Namespace: rc.Instance.Namespace}),
+	}
 }
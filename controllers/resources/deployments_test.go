@@ -0,0 +1,67 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+func TestDeploymentMutateReplicas(t *testing.T) {
+	enabled, disabled := true, false
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	tests := []struct {
+		name      string
+		autoScale *operatorsv1beta1.AutoScaleConfig
+		legacy    bool
+		existing  *int32
+		want      int32
+	}{
+		{name: "replicas of the CR", existing: int32Ptr(3), want: 1},
+		{name: "scaled to 0", existing: int32Ptr(0), want: 0},
+		{name: "autoscaler disabled", autoScale: &operatorsv1beta1.AutoScaleConfig{Enabled: &disabled}, existing: int32Ptr(3), want: 1},
+		{name: "replicas set by the autoscaler", autoScale: &operatorsv1beta1.AutoScaleConfig{Enabled: &enabled}, existing: int32Ptr(5), want: 5},
+		{name: "replicas set by the autoscaler of the deprecated boolean", legacy: true, existing: int32Ptr(5), want: 5},
+		{name: "autoscaler disabled over the deprecated boolean", autoScale: &operatorsv1beta1.AutoScaleConfig{Enabled: &disabled}, legacy: true,
+			existing: int32Ptr(3), want: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := &operatorsv1beta1.CommonWebUI{}
+			instance.Spec.AutoScaling = test.autoScale
+			instance.Spec.AutoScaleConfig = test.legacy
+			rc := &ReconcileContext{Instance: instance}
+
+			existing := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: test.existing}}
+			desired := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: int32Ptr(1)}}
+			deploymentResource{}.Mutate(rc, existing, desired)
+
+			//The apply owns spec.replicas, a desired deployment without replicas would reset them to 1
+			if desired.Spec.Replicas == nil {
+				t.Fatalf("expected the replicas to be applied")
+			}
+			if *desired.Spec.Replicas != test.want {
+				t.Errorf("expected %d replicas, got %d", test.want, *desired.Spec.Replicas)
+			}
+		})
+	}
+}
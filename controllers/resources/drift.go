@@ -0,0 +1,167 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// maxDriftFields limits the number of fields reported per resource so the CR status stays small
+const maxDriftFields = 20
+
+// maxDriftValueLength limits the length of the values shown in a drift field
+const maxDriftValueLength = 64
+
+// Metadata that is maintained by the API server and changes with every write
+var ignoredDriftMetadata = []string{"resourceVersion", "generation", "managedFields", "creationTimestamp", "uid", "selfLink"}
+
+// diffObjects returns the fields that differ between the object before and after an apply, in the form
+// `path: before -> after`. The status and the metadata maintained by the API server are not compared.
+func diffObjects(before, after client.Object) ([]string, error) {
+	beforeMap, err := driftContent(before)
+	if err != nil {
+		return nil, err
+	}
+	afterMap, err := driftContent(after)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []string{}
+	diffValues("", beforeMap, afterMap, &fields)
+	if len(fields) > maxDriftFields {
+		more := len(fields) - maxDriftFields
+		fields = append(fields[:maxDriftFields], fmt.Sprintf("... %d more", more))
+	}
+	return fields, nil
+}
+
+func driftContent(obj client.Object) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	//The content of an unstructured object is not copied by the converter
+	if _, ok := obj.(runtime.Unstructured); ok {
+		content = runtime.DeepCopyJSON(content)
+	}
+	delete(content, "status")
+	delete(content, "apiVersion")
+	delete(content, "kind")
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		for _, field := range ignoredDriftMetadata {
+			delete(metadata, field)
+		}
+	}
+	return content, nil
+}
+
+func diffValues(path string, before, after interface{}, fields *[]string) {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		keys := map[string]bool{}
+		for key := range beforeMap {
+			keys[key] = true
+		}
+		for key := range afterMap {
+			keys[key] = true
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+		for _, key := range sortedKeys {
+			diffValues(joinDriftPath(path, key), beforeMap[key], afterMap[key], fields)
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]interface{})
+	afterList, afterIsList := after.([]interface{})
+	if beforeIsList && afterIsList {
+		length := len(beforeList)
+		if len(afterList) > length {
+			length = len(afterList)
+		}
+		for i := 0; i < length; i++ {
+			var beforeItem, afterItem interface{}
+			if i < len(beforeList) {
+				beforeItem = beforeList[i]
+			}
+			if i < len(afterList) {
+				afterItem = afterList[i]
+			}
+			diffValues(path+"["+strconv.Itoa(i)+"]", beforeItem, afterItem, fields)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*fields = append(*fields, fmt.Sprintf("%s: %s -> %s", path, formatDriftValue(before), formatDriftValue(after)))
+	}
+}
+
+func joinDriftPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func formatDriftValue(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	formatted := ""
+	if s, ok := value.(string); ok {
+		formatted = s
+	} else if raw, err := json.Marshal(value); err == nil {
+		formatted = string(raw)
+	} else {
+		formatted = fmt.Sprintf("%v", value)
+	}
+	if len(formatted) > maxDriftValueLength {
+		formatted = formatted[:maxDriftValueLength] + "..."
+	}
+	return formatted
+}
+
+// SetLastDrift records the reverted resources in the CR status and counts them in the drift metric
+func SetLastDrift(instance *operatorsv1beta1.CommonWebUI, drifts []operatorsv1beta1.ResourceDrift) {
+	if len(drifts) == 0 {
+		return
+	}
+	for _, drift := range drifts {
+		driftCorrections.WithLabelValues(drift.Kind).Inc()
+	}
+	instance.Status.LastDrift = &operatorsv1beta1.DriftReport{
+		Time:      metav1.Now(),
+		Resources: drifts,
+	}
+}
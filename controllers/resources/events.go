@@ -0,0 +1,133 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// Reasons of the Normal events recorded on the CommonWebUI
+const EventReasonCreated = "Created"
+const EventReasonUpdated = "Updated"
+const EventReasonPatched = "Patched"
+const EventReasonDeleted = "Deleted"
+const EventReasonUnmanaged = "Unmanaged"
+const EventReasonManaged = "Managed"
+
+// EventClient is a client that records an event on the CommonWebUI for every resource it creates,
+// updates or deletes, so that `kubectl describe commonwebui` shows what the operator last did
+type EventClient struct {
+	client.Client
+	recorder record.EventRecorder
+	instance *operatorsv1beta1.CommonWebUI
+}
+
+// NewEventClient wraps the client so that its write operations are recorded as events on the instance
+func NewEventClient(c client.Client, recorder record.EventRecorder, instance *operatorsv1beta1.CommonWebUI) client.Client {
+	if recorder == nil {
+		return c
+	}
+	return &EventClient{Client: c, recorder: recorder, instance: instance}
+}
+
+func (c *EventClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	if err == nil {
+		c.recordEvent(obj, EventReasonCreated)
+	}
+	return err
+}
+
+func (c *EventClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := c.Client.Update(ctx, obj, opts...)
+	if err == nil {
+		c.recordEvent(obj, EventReasonUpdated)
+	}
+	return err
+}
+
+func (c *EventClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	//Applies are recorded by observeApply once it is known whether they changed anything
+	if err == nil && patch.Type() != types.ApplyPatchType {
+		c.recordEvent(obj, EventReasonPatched)
+	}
+	return err
+}
+
+func (c *EventClient) observeApply(obj client.Object, result controllerutil.OperationResult) {
+	if result == controllerutil.OperationResultCreated {
+		c.recordEvent(obj, EventReasonCreated)
+	} else {
+		c.recordEvent(obj, EventReasonUpdated)
+	}
+	if observer, ok := c.Client.(applyObserver); ok {
+		observer.observeApply(obj, result)
+	}
+}
+
+func (c *EventClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	if err == nil {
+		c.recordEvent(obj, EventReasonDeleted)
+	}
+	return err
+}
+
+func (c *EventClient) recordEvent(obj client.Object, reason string) {
+	//Changes to the CR itself, such as removing finalizers, are not reported on the CR
+	if obj.GetUID() != "" && obj.GetUID() == c.instance.UID {
+		return
+	}
+
+	kind := "resource"
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	c.recorder.Eventf(c.instance, corev1.EventTypeNormal, reason, "%s %s %s", reason, kind, obj.GetName())
+}
+
+// RecordUnmanagedEvents records an event for each managed object that was put under or released from manual
+// control with the unmanaged annotation since the previous status
+func RecordUnmanagedEvents(recorder record.EventRecorder, instance *operatorsv1beta1.CommonWebUI, previous, current operatorsv1beta1.ServiceStatus) {
+	if recorder == nil {
+		return
+	}
+	wasUnmanaged := map[string]bool{}
+	for _, status := range previous.ManagedResources {
+		wasUnmanaged[status.Kind+"/"+status.ObjectName] = status.Unmanaged
+	}
+	for _, status := range current.ManagedResources {
+		key := status.Kind + "/" + status.ObjectName
+		if status.Unmanaged && !wasUnmanaged[key] {
+			recorder.Eventf(instance, corev1.EventTypeNormal, EventReasonUnmanaged, "%s %s is unmanaged, the operator does not change it",
+				status.Kind, status.ObjectName)
+		} else if !status.Unmanaged && wasUnmanaged[key] {
+			recorder.Eventf(instance, corev1.EventTypeNormal, EventReasonManaged, "%s %s is managed by the operator again",
+				status.Kind, status.ObjectName)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	errorf "errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// The Gateway API types are not part of the operator dependencies, the objects are managed as unstructured
+// objects in the version that is served by the cluster
+const GatewayAPIGroup = "gateway.networking.k8s.io"
+
+var HTTPRouteGVK = schema.GroupVersionKind{Group: GatewayAPIGroup, Version: "v1", Kind: "HTTPRoute"}
+var BackendTLSPolicyGVK = schema.GroupVersionKind{Group: GatewayAPIGroup, Version: "v1", Kind: "BackendTLSPolicy"}
+
+const HTTPRouteName = "cp-console"
+const BackendTLSPolicyName = "common-web-ui"
+
+// GatewayCAConfigMapName holds the CA of the common-web-ui certificate, a BackendTLSPolicy can only
+// reference a CA in a configmap
+const GatewayCAConfigMapName = "common-web-ui-ca-bundle"
+
+// ErrGatewayNotConfigured is returned when the Gateway exposure mode is selected without a gateway
+var ErrGatewayNotConfigured = errorf.New("spec.exposure.gateway.parentRef.name is required for the Gateway exposure mode")
+
+// gatewayAPIVersions are the versions of the Gateway API kinds that the operator can build, in order of
+// preference. The BackendTLSPolicy of v1alpha2 has a different spec than the later versions.
+var gatewayAPIVersions = map[string][]string{
+	"HTTPRoute":        {"v1", "v1beta1"},
+	"BackendTLSPolicy": {"v1", "v1alpha3", "v1alpha2"},
+}
+
+// GetGatewayAPIKind returns the kind of the Gateway API in the first supported version served by the cluster.
+// The default version is returned with false when the cluster serves none of the supported versions.
+func GetGatewayAPIKind(k8sClient client.Client, gvk schema.GroupVersionKind) (schema.GroupVersionKind, bool) {
+	mapping, err := k8sClient.RESTMapper().RESTMapping(gvk.GroupKind(), gatewayAPIVersions[gvk.Kind]...)
+	if err != nil {
+		return gvk, false
+	}
+	return mapping.GroupVersionKind, true
+}
+
+func isGatewayConfigured(instance *operatorsv1beta1.CommonWebUI) bool {
+	exposure := instance.Spec.Exposure
+	return exposure != nil && exposure.Gateway != nil && exposure.Gateway.ParentRef.Name != ""
+}
+
+// GetExposureMode returns how the console is exposed for the CR, the mode in the CR is used when it is set
+func GetExposureMode(rc *ReconcileContext) string {
+	if rc.Instance.Spec.Exposure != nil && rc.Instance.Spec.Exposure.Mode != "" {
+		return rc.Instance.Spec.Exposure.Mode
+	}
+	if !rc.IsCncf {
+		return operatorsv1beta1.ExposureModeRoute
+	}
+	if IsIngressEnabled(rc.Instance) {
+		return operatorsv1beta1.ExposureModeIngress
+	}
+	if isGatewayConfigured(rc.Instance) {
+		if _, served := GetGatewayAPIKind(rc.Client, HTTPRouteGVK); served {
+			return operatorsv1beta1.ExposureModeGateway
+		}
+	}
+	return operatorsv1beta1.ExposureModeNone
+}
+
+func newGatewayAPIObject(rc *ReconcileContext, gvk schema.GroupVersionKind, name string) *unstructured.Unstructured {
+	gvk, _ = GetGatewayAPIKind(rc.Client, gvk)
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(rc.Instance.Namespace)
+	return obj
+}
+
+func getDesiredHTTPRoute(rc *ReconcileContext, host string) (*unstructured.Unstructured, error) {
+	instance := rc.Instance
+	reqLogger := log.WithValues("func", "getDesiredHTTPRoute", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	parentRef := instance.Spec.Exposure.Gateway.ParentRef
+	parent := map[string]interface{}{
+		"group":     GatewayAPIGroup,
+		"kind":      "Gateway",
+		"name":      parentRef.Name,
+		"namespace": GetStringWithDefault(parentRef.Namespace, instance.Namespace),
+	}
+	if parentRef.SectionName != "" {
+		parent["sectionName"] = parentRef.SectionName
+	}
+
+	httpRoute := newGatewayAPIObject(rc, HTTPRouteGVK, HTTPRouteName)
+	httpRoute.SetLabels(MergeMap(LabelsForMetadata(HTTPRouteName), instance.Spec.Labels))
+	httpRoute.Object["spec"] = map[string]interface{}{
+		"parentRefs": []interface{}{parent},
+		"hostnames":  []interface{}{host},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  "PathPrefix",
+							"value": CnRoutePath,
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": ServiceName,
+						"port": int64(3000),
+					},
+				},
+			},
+		},
+	}
+
+	err := controllerutil.SetControllerReference(instance, httpRoute, rc.Client.Scheme())
+	if err != nil {
+		reqLogger.Error(err, "Failed to set owner for HTTPRoute")
+		return nil, err
+	}
+
+	return httpRoute, nil
+}
+
+func getDesiredBackendTLSPolicy(rc *ReconcileContext) (*unstructured.Unstructured, error) {
+	instance := rc.Instance
+	reqLogger := log.WithValues("func", "getDesiredBackendTLSPolicy", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	policy := newGatewayAPIObject(rc, BackendTLSPolicyGVK, BackendTLSPolicyName)
+	policy.SetLabels(MergeMap(LabelsForMetadata(BackendTLSPolicyName), instance.Spec.Labels))
+
+	service := map[string]interface{}{
+		"group": "",
+		"kind":  "Service",
+		"name":  ServiceName,
+	}
+	caConfigMap := map[string]interface{}{
+		"group": "",
+		"kind":  "ConfigMap",
+		"name":  GatewayCAConfigMapName,
+	}
+	//The service name is one of the DNS names of the common-web-ui certificate
+	hostname := UICertCommonName + "." + instance.Namespace + ".svc.cluster.local"
+
+	if policy.GroupVersionKind().Version == "v1alpha2" {
+		policy.Object["spec"] = map[string]interface{}{
+			"targetRef": service,
+			"tls": map[string]interface{}{
+				"caCertRefs": []interface{}{caConfigMap},
+				"hostname":   hostname,
+			},
+		}
+	} else {
+		policy.Object["spec"] = map[string]interface{}{
+			"targetRefs": []interface{}{service},
+			"validation": map[string]interface{}{
+				"caCertificateRefs": []interface{}{caConfigMap},
+				"hostname":          hostname,
+			},
+		}
+	}
+
+	err := controllerutil.SetControllerReference(instance, policy, rc.Client.Scheme())
+	if err != nil {
+		reqLogger.Error(err, "Failed to set owner for BackendTLSPolicy")
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// gatewayCAConfigMapResource copies the CA of the common-web-ui certificate for the BackendTLSPolicy
+type gatewayCAConfigMapResource struct{}
+
+func (gatewayCAConfigMapResource) Name() string {
+	return "GatewayCAConfigMap"
+}
+
+func (gatewayCAConfigMapResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonGatewayFailed
+}
+
+func (gatewayCAConfigMapResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return GetExposureMode(rc) == operatorsv1beta1.ExposureModeGateway
+}
+
+func (gatewayCAConfigMapResource) Object(rc *ReconcileContext) client.Object {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: GatewayCAConfigMapName, Namespace: rc.Instance.Namespace}}
+}
+
+func (gatewayCAConfigMapResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	instance := rc.Instance
+	reqLogger := log.WithValues("func", "gatewayCAConfigMapResource.Desired", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	certSecretName := GetCertificateSecretName(instance)
+	secret := &corev1.Secret{}
+	err := rc.Reader().Get(ctx, types.NamespacedName{Name: certSecretName, Namespace: instance.Namespace}, secret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("Certificate secret does not exist yet - skipping the CA configmap", "SecretName", certSecretName)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(secret.Data["ca.crt"]) == 0 {
+		reqLogger.Info("Certificate secret has no CA yet - skipping the CA configmap", "SecretName", certSecretName)
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GatewayCAConfigMapName,
+			Namespace: instance.Namespace,
+			Labels:    MergeMap(LabelsForMetadata(GatewayCAConfigMapName), instance.Spec.Labels),
+		},
+		Data: map[string]string{
+			"ca.crt": string(secret.Data["ca.crt"]),
+		},
+	}
+	return cm, setControllerReference(rc, cm)
+}
+
+func (gatewayCAConfigMapResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
+
+func (gatewayCAConfigMapResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return nil
+}
+
+// httpRouteResource is the cp-console HTTPRoute of the Gateway exposure mode
+type httpRouteResource struct{}
+
+func (httpRouteResource) Name() string {
+	return "HTTPRoute"
+}
+
+func (httpRouteResource) FailedReason(err error) string {
+	if errorf.Is(err, ErrClusterAddressMissing) {
+		return operatorsv1beta1.ReasonClusterInfoMissing
+	}
+	return operatorsv1beta1.ReasonGatewayFailed
+}
+
+func (httpRouteResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return GetExposureMode(rc) == operatorsv1beta1.ExposureModeGateway
+}
+
+func (httpRouteResource) Object(rc *ReconcileContext) client.Object {
+	return newGatewayAPIObject(rc, HTTPRouteGVK, HTTPRouteName)
+}
+
+func (httpRouteResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	if !isGatewayConfigured(rc.Instance) {
+		return nil, ErrGatewayNotConfigured
+	}
+
+	host, err := getClusterAddress(ctx, rc.Client, rc.Instance.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return getDesiredHTTPRoute(rc, host)
+}
+
+func (httpRouteResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
+
+func (httpRouteResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return []operatorsv1beta1.ManagedResourceStatus{
+		getGatewayAPIStatus(ctx, rc.Client, newGatewayAPIObject(rc, HTTPRouteGVK, HTTPRouteName), "parents"),
+	}
+}
+
+// backendTLSPolicyResource makes the gateway connect to the common-web-ui service over TLS. It is only
+// managed when the cluster serves BackendTLSPolicy in v1, v1alpha3 or v1alpha2.
+type backendTLSPolicyResource struct{}
+
+func (backendTLSPolicyResource) Name() string {
+	return "BackendTLSPolicy"
+}
+
+func (backendTLSPolicyResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonGatewayFailed
+}
+
+func (backendTLSPolicyResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	if GetExposureMode(rc) != operatorsv1beta1.ExposureModeGateway {
+		return false
+	}
+	if _, served := GetGatewayAPIKind(rc.Client, BackendTLSPolicyGVK); !served {
+		log.Info("BackendTLSPolicy is not served in a supported version - the gateway connection to common-web-ui is not configured for TLS",
+			"instance.Namespace", rc.Instance.Namespace)
+		return false
+	}
+	return true
+}
+
+func (backendTLSPolicyResource) Object(rc *ReconcileContext) client.Object {
+	return newGatewayAPIObject(rc, BackendTLSPolicyGVK, BackendTLSPolicyName)
+}
+
+func (backendTLSPolicyResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	return getDesiredBackendTLSPolicy(rc)
+}
+
+func (backendTLSPolicyResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
+
+func (backendTLSPolicyResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return []operatorsv1beta1.ManagedResourceStatus{
+		getGatewayAPIStatus(ctx, rc.Client, newGatewayAPIObject(rc, BackendTLSPolicyGVK, BackendTLSPolicyName), "ancestors"),
+	}
+}
+
+// A Gateway API object is ready once a gateway has accepted it and none of its gateways reports a problem.
+// The gateways report their conditions in status.parents (routes) or status.ancestors (policies).
+func getGatewayAPIStatus(ctx context.Context, k8sClient client.Client, obj *unstructured.Unstructured, statusField string) (status operatorsv1beta1.ManagedResourceStatus) {
+	reqLogger := log.WithValues("func", "getGatewayAPIStatus", "kind", obj.GetKind(), "name", obj.GetName())
+
+	status = operatorsv1beta1.ManagedResourceStatus{
+		ObjectName: obj.GetName(),
+		APIVersion: Unknown,
+		Namespace:  obj.GetNamespace(),
+		Kind:       obj.GetKind(),
+		Status:     NotReady,
+	}
+	err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			reqLogger.Error(err, "Error reading gateway API object for status update")
+		}
+		return
+	}
+	status.APIVersion = obj.GetAPIVersion()
+
+	parents, _, _ := unstructured.NestedSlice(obj.Object, "status", statusField)
+	accepted := false
+	for _, parent := range parents {
+		parentMap, ok := parent.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(parentMap, "conditions")
+		for _, condition := range conditions {
+			conditionMap, ok := condition.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			conditionType := fmt.Sprint(conditionMap["type"])
+			conditionStatus := fmt.Sprint(conditionMap["status"])
+			if (conditionType == "Accepted" || conditionType == "ResolvedRefs") && conditionStatus != string(metav1.ConditionTrue) {
+				return
+			}
+			if conditionType == "Accepted" {
+				accepted = true
+			}
+		}
+	}
+	if accepted {
+		status.Status = Ready
+	}
+	return
+}
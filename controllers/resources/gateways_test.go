@@ -0,0 +1,76 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+func TestGetDesiredBackendTLSPolicy(t *testing.T) {
+	tests := []struct {
+		servedVersion string
+		// spec fields that are expected in the served version
+		fields [][]string
+	}{
+		{servedVersion: "v1", fields: [][]string{{"targetRefs"}, {"validation", "caCertificateRefs"}, {"validation", "hostname"}}},
+		{servedVersion: "v1alpha3", fields: [][]string{{"targetRefs"}, {"validation", "caCertificateRefs"}, {"validation", "hostname"}}},
+		{servedVersion: "v1alpha2", fields: [][]string{{"targetRef"}, {"tls", "caCertRefs"}, {"tls", "hostname"}}},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorsv1beta1.AddToScheme(scheme)
+
+	for _, test := range tests {
+		t.Run(test.servedVersion, func(t *testing.T) {
+			gv := schema.GroupVersion{Group: GatewayAPIGroup, Version: test.servedVersion}
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+			mapper.Add(gv.WithKind("BackendTLSPolicy"), meta.RESTScopeNamespace)
+
+			instance := &operatorsv1beta1.CommonWebUI{}
+			instance.Name = "example-commonwebui"
+			instance.Namespace = "cs"
+			rc := &ReconcileContext{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build(),
+				Instance: instance,
+				IsCncf:   true,
+			}
+
+			policy, err := getDesiredBackendTLSPolicy(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if policy.GroupVersionKind().Version != test.servedVersion {
+				t.Errorf("expected version %s, got %s", test.servedVersion, policy.GroupVersionKind().Version)
+			}
+			for _, field := range test.fields {
+				if _, found, _ := unstructured.NestedFieldNoCopy(policy.Object, append([]string{"spec"}, field...)...); !found {
+					t.Errorf("expected spec field %v in %s, got %v", field, test.servedVersion, policy.Object["spec"])
+				}
+			}
+		})
+	}
+}
@@ -20,17 +20,23 @@ import (
 	"context"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
-	"k8s.io/apimachinery/pkg/api/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 )
 
-func getDesiredHorizontalPodAutoscaler(client client.Client, instance *operatorsv1alpha1.CommonWebUI) (*autoscalingv2.HorizontalPodAutoscaler, error) {
-	reqLogger := log.WithValues("func", "getDesiredHorizontalPodAutoscaler", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+// GetEffectiveAutoScaling returns the autoscaler settings of the CR with the unset fields derived from
+// the replicas and memory resources. Returns nil when autoscaling is off.
+func GetEffectiveAutoScaling(instance *operatorsv1beta1.CommonWebUI) *operatorsv1beta1.EffectiveAutoScaling {
+	reqLogger := log.WithValues("func", "GetEffectiveAutoScaling", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	config := instance.Spec.GetAutoScaling()
+	if !config.IsEnabled() {
+		return nil
+	}
 
 	//Determine min and max replicas
 	//The formula is tshirt size of medium or > then use 2 for min and 2x+1 for max
@@ -43,20 +49,120 @@ func getDesiredHorizontalPodAutoscaler(client client.Client, instance *operators
 		maxReplicas = (instance.Spec.Replicas * 2) + 1
 	}
 
-	//Determine average utilization
-	//The formula is when the limit is < 130% of request then use 90,
-	//When the gap is greater than 130%, then use (limit * .7)/request * 100
-	var averageUtilization int32 = 90
-	request := int32(GetResourceMemoryWithDefault(instance.Spec.Resources.Requests.RequestMemory, 512))
-	limit := int32(GetResourceMemoryWithDefault(instance.Spec.Resources.Limits.CPUMemory, 512))
+	if config.MinReplicas != nil {
+		minReplicas = *config.MinReplicas
+	}
+	if config.MaxReplicas != nil {
+		maxReplicas = *config.MaxReplicas
+	}
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+
+	var metrics []autoscalingv2.MetricSpec
+	if config.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, resourceUtilizationMetric(corev1.ResourceCPU, *config.TargetCPUUtilizationPercentage))
+	}
+	if config.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceUtilizationMetric(corev1.ResourceMemory, *config.TargetMemoryUtilizationPercentage))
+	}
+	for _, metric := range config.Metrics {
+		metrics = append(metrics, *metric.DeepCopy())
+	}
+
+	//Without any configured metric, scale on memory as before
+	if len(metrics) == 0 {
+		//Determine average utilization
+		//The formula is when the limit is < 130% of request then use 90,
+		//When the gap is greater than 130%, then use (limit * .7)/request * 100
+		var averageUtilization int32 = 90
+		resources := getEffectiveResources(instance)
+		requestQuantity := resources.Requests[corev1.ResourceMemory]
+		limitQuantity := resources.Limits[corev1.ResourceMemory]
+		request := requestQuantity.Value()
+		limit := limitQuantity.Value()
+
+		reqLogger.V(1).Info("computing average utilization", "request", request, "limit", limit, "base utilization (limit/request)*100", (float64(limit)/float64(request))*100)
+
+		//When the gap between limit and request is > 130, bump averageUtilization
+		if request > 0 && (float64(limit)/float64(request))*100 > 130 {
+			averageUtilization = int32(float64(limit*70) / float64(request))
+			reqLogger.V(1).Info("Setting large gap utilization", "averageUtilization", averageUtilization)
+		}
+
+		metrics = append(metrics, resourceUtilizationMetric(corev1.ResourceMemory, averageUtilization))
+	}
+
+	var behavior *autoscalingv2.HorizontalPodAutoscalerBehavior
+	if config.Behavior != nil {
+		behavior = config.Behavior.DeepCopy()
+		setDefaultsHPABehavior(behavior)
+	}
+
+	return &operatorsv1beta1.EffectiveAutoScaling{
+		MinReplicas: minReplicas,
+		MaxReplicas: maxReplicas,
+		Metrics:     metrics,
+		Behavior:    behavior,
+	}
+}
+
+func resourceUtilizationMetric(name corev1.ResourceName, averageUtilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &averageUtilization,
+			},
+		},
+	}
+}
+
+// setDefaultsHPABehavior fills in the defaults the API server sets on a partial behavior, so the status
+// shows the behavior the HPA really uses
+func setDefaultsHPABehavior(behavior *autoscalingv2.HorizontalPodAutoscalerBehavior) {
+	maxPolicy := autoscalingv2.MaxChangePolicySelect
 
-	reqLogger.Info("computing average utilization", "request", request, "limit", limit, "base utilization (limit/request)*100", (float64(limit)/float64(request))*100)
+	if behavior.ScaleUp == nil {
+		behavior.ScaleUp = &autoscalingv2.HPAScalingRules{}
+	}
+	if behavior.ScaleUp.StabilizationWindowSeconds == nil {
+		var scaleUpStabilizationWindowSeconds int32
+		behavior.ScaleUp.StabilizationWindowSeconds = &scaleUpStabilizationWindowSeconds
+	}
+	if behavior.ScaleUp.SelectPolicy == nil {
+		behavior.ScaleUp.SelectPolicy = &maxPolicy
+	}
+	if len(behavior.ScaleUp.Policies) == 0 {
+		behavior.ScaleUp.Policies = []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		}
+	}
 
-	//When the gap between limit and request is > 130, bump averageUtilization
-	if (float64(limit)/float64(request))*100 > 130 {
-		averageUtilization = int32(float64(limit*70) / float64(request))
-		reqLogger.Info("Setting large gap utilization", "averageUtilization", averageUtilization)
+	if behavior.ScaleDown == nil {
+		behavior.ScaleDown = &autoscalingv2.HPAScalingRules{}
+	}
+	if behavior.ScaleDown.StabilizationWindowSeconds == nil {
+		var scaleDownStabilizationWindowSeconds int32 = 300
+		behavior.ScaleDown.StabilizationWindowSeconds = &scaleDownStabilizationWindowSeconds
+	}
+	if behavior.ScaleDown.SelectPolicy == nil {
+		behavior.ScaleDown.SelectPolicy = &maxPolicy
 	}
+	if len(behavior.ScaleDown.Policies) == 0 {
+		behavior.ScaleDown.Policies = []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		}
+	}
+}
+
+func getDesiredHorizontalPodAutoscaler(client client.Client, instance *operatorsv1beta1.CommonWebUI) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	reqLogger := log.WithValues("func", "getDesiredHorizontalPodAutoscaler", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	autoScaling := GetEffectiveAutoScaling(instance)
 
 	metaLabels := MergeMap(LabelsForMetadata(HPAName), instance.Spec.Labels)
 	hpa := &autoscalingv2.HorizontalPodAutoscaler{
@@ -71,20 +177,10 @@ func getDesiredHorizontalPodAutoscaler(client client.Client, instance *operators
 				Name:       DeploymentName,
 				APIVersion: "apps/v1",
 			},
-			MinReplicas: &minReplicas,
-			MaxReplicas: maxReplicas,
-			Metrics: []autoscalingv2.MetricSpec{
-				{
-					Type: autoscalingv2.ResourceMetricSourceType,
-					Resource: &autoscalingv2.ResourceMetricSource{
-						Name: "memory",
-						Target: autoscalingv2.MetricTarget{
-							Type:               "Utilization",
-							AverageUtilization: &averageUtilization,
-						},
-					},
-				},
-			},
+			MinReplicas: &autoScaling.MinReplicas,
+			MaxReplicas: autoScaling.MaxReplicas,
+			Metrics:     autoScaling.Metrics,
+			Behavior:    autoScaling.Behavior,
 		},
 	}
 
@@ -97,79 +193,34 @@ func getDesiredHorizontalPodAutoscaler(client client.Client, instance *operators
 	return hpa, nil
 }
 
-func ReconcileHorizontalPodAutoscaler(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileHorizontalPodAutoscaler", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling HPA")
-
-	if !instance.Spec.AutoScaleConfig {
-		//Horizontal pod autoscaling is disabled, delete the hpa if it exists
-		reqLogger.Info("HPA disabled - delete HPA if it exists", "routeName", HPAName)
-
-		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
-		err := client.Get(ctx, types.NamespacedName{Name: HPAName, Namespace: instance.Namespace}, hpa)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				reqLogger.Info("HPA not found - deletion is skipped", "Name", HPAName, "Namespace", instance.Namespace)
-			} else {
-				reqLogger.Error(err, "Unable to read the HPA for deletion - deletion skipped, but reconciliation will proceed")
-			}
-			return nil //Do not stop reconciliation if there was an error
-		}
-		err = client.Delete(ctx, hpa)
-		if err != nil {
-			reqLogger.Error(err, "Error deleting HPA - reconciliation will proceed")
-		} else {
-			reqLogger.Info("HPA deleted")
-		}
-		return nil //Do not stop reconciliation if there was an error
-	}
-
-	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
-	desiredHPA, desiredErr := getDesiredHorizontalPodAutoscaler(client, instance)
-	if desiredErr != nil {
-		return desiredErr
-	}
-
-	err := client.Get(ctx, types.NamespacedName{Name: HPAName, Namespace: instance.Namespace}, hpa)
-
-	if err != nil && errors.IsNotFound(err) {
-		reqLogger.Info("Creating a new HPA", "HPA.Namespace", desiredHPA.Namespace, "HPA.Name", desiredHPA.Name)
-
-		err = client.Create(ctx, desiredHPA)
-		if err != nil {
-			if errors.IsAlreadyExists(err) {
-				// HPA already exists from a previous reconcile
-				reqLogger.Info("HPA already exists")
-				*needToRequeue = true
-			} else {
-				// Failed to create a new HPA
-				reqLogger.Info("Failed to create a new HPA", "HPA.Namespace", desiredHPA.Namespace, "HPA.Name", desiredHPA.Name)
-				return err
-			}
-		} else {
-			// Requeue after creating new HPA
-			*needToRequeue = true
-		}
-	} else if err != nil && !errors.IsNotFound(err) {
-		reqLogger.Error(err, "Failed to get HPA", "HPA.Namespace", instance.Namespace, "HPA.Name", HPAName)
-		return err
-	} else {
-		// Determine if current HPA has changed
-		reqLogger.Info("Comparing current and desired HPAs")
-
-		if !IsHorizontalPodscalerEqual(hpa, desiredHPA) {
-			reqLogger.Info("Updating HPA", "Namespace", hpa.Namespace, "Name", hpa.Name)
-			hpa.ObjectMeta.Name = desiredHPA.ObjectMeta.Name
-			hpa.ObjectMeta.Labels = desiredHPA.ObjectMeta.Labels
-			hpa.Spec = desiredHPA.Spec
-
-			err = client.Update(ctx, hpa)
-			if err != nil {
-				reqLogger.Error(err, "Failed to update HPA", "Namespace", hpa.Namespace, "Name", hpa.Name)
-				return err
-			}
-		}
-	}
+// horizontalPodAutoscalerResource is the HPA of the common-web-ui deployment, it only exists when
+// autoscaling is enabled in the CR
+type horizontalPodAutoscalerResource struct{}
+
+func (horizontalPodAutoscalerResource) Name() string {
+	return "HorizontalPodAutoscaler"
+}
+
+func (horizontalPodAutoscalerResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonHPAFailed
+}
+
+func (horizontalPodAutoscalerResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return rc.Instance.Spec.GetAutoScaling().IsEnabled()
+}
+
+func (horizontalPodAutoscalerResource) Object(rc *ReconcileContext) client.Object {
+	return &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: HPAName, Namespace: rc.Instance.Namespace}}
+}
+
+func (horizontalPodAutoscalerResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	return getDesiredHorizontalPodAutoscaler(rc.Client, rc.Instance)
+}
+
+func (horizontalPodAutoscalerResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
 
+func (horizontalPodAutoscalerResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
 	return nil
 }
@@ -18,6 +18,7 @@ package resources
 
 import (
 	"context"
+	errorf "errors"
 
 	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -26,12 +27,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 )
 
-// type DesiredStateGetter func(ctx context.Context, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) (*netv1.Ingress, error)
-
-func ReconcileRemoveIngresses(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) {
+func ReconcileRemoveIngresses(ctx context.Context, client client.Client, instance *operatorsv1beta1.CommonWebUI, needToRequeue *bool) {
 	reqLogger := log.WithValues("func", "ReconcileRemoveIngresses")
 
 	// Check if operator has required Ingress permissions in the instance namespace
@@ -88,145 +87,43 @@ func DeleteIngress(ctx context.Context, client client.Client, ingressName string
 	return nil
 }
 
-func getDesiredAPIIngress(client client.Client, instance *operatorsv1alpha1.CommonWebUI, isCncf bool) (*netv1.Ingress, error) {
-	reqLogger := log.WithValues("func", "getDesiredAPIIngress", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-
-	metaLabels := LabelsForMetadata(APIIngressName)
-
-	ingressPath := instance.Spec.CommonWebUIConfig.IngressPath
-	apiIngressPath := ingressPath + "/api/"
-	logoutIngressPath := ingressPath + "/logout/"
-
-	pathType := netv1.PathType("ImplementationSpecific")
-	var ingress *netv1.Ingress
-
-	if isCncf {
-		ingress = &netv1.Ingress{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        APIIngressName,
-				Annotations: APIIngressAnnotations,
-				Labels:      metaLabels,
-				Namespace:   instance.Namespace,
-			},
-			Spec: netv1.IngressSpec{
-				Rules: []netv1.IngressRule{
-					{
-						IngressRuleValue: netv1.IngressRuleValue{
-							HTTP: &netv1.HTTPIngressRuleValue{
-								Paths: []netv1.HTTPIngressPath{
-									{
-										Path:     apiIngressPath,
-										PathType: &pathType,
-										Backend: netv1.IngressBackend{
-											Service: &netv1.IngressServiceBackend{
-												Name: instance.Spec.CommonWebUIConfig.ServiceName,
-												Port: netv1.ServiceBackendPort{
-													Number: 3000,
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		}
-	} else {
-		ingress = &netv1.Ingress{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        APIIngressName,
-				Annotations: APIIngressAnnotations,
-				Labels:      metaLabels,
-				Namespace:   instance.Namespace,
-			},
-			Spec: netv1.IngressSpec{
-				Rules: []netv1.IngressRule{
-					{
-						IngressRuleValue: netv1.IngressRuleValue{
-							HTTP: &netv1.HTTPIngressRuleValue{
-								Paths: []netv1.HTTPIngressPath{
-									{
-										Path:     apiIngressPath,
-										PathType: &pathType,
-										Backend: netv1.IngressBackend{
-											Service: &netv1.IngressServiceBackend{
-												Name: instance.Spec.CommonWebUIConfig.ServiceName,
-												Port: netv1.ServiceBackendPort{
-													Number: 3000,
-												},
-											},
-										},
-									},
-									{
-										Path:     logoutIngressPath,
-										PathType: &pathType,
-										Backend: netv1.IngressBackend{
-											Service: &netv1.IngressServiceBackend{
-												Name: instance.Spec.CommonWebUIConfig.ServiceName,
-												Port: netv1.ServiceBackendPort{
-													Number: 3000,
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		}
-	}
-
-	err := controllerutil.SetControllerReference(instance, ingress, client.Scheme())
-	if err != nil {
-		reqLogger.Error(err, "Failed to set owner for API ingress")
-		return nil, err
-	}
-
-	return ingress, nil
-}
-
-func ReconcileAPIIngress(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, isCncf bool, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileAPIIngress", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling API ingress")
-
-	desiredIngress, err := getDesiredAPIIngress(client, instance, isCncf)
-	if err != nil {
-		return err
-	}
-
-	return reconcileIngress(ctx, client, instance, APIIngressName, desiredIngress, needToRequeue)
+// IsIngressEnabled returns true if the managed cp-console Ingress is turned on in the CR
+func IsIngressEnabled(instance *operatorsv1beta1.CommonWebUI) bool {
+	return instance.Spec.Ingress != nil && instance.Spec.Ingress.Enabled
 }
 
-// nolint
-func getDesiredCallbackIngress(client client.Client, instance *operatorsv1alpha1.CommonWebUI) (*netv1.Ingress, error) {
-	reqLogger := log.WithValues("func", "getDesiredCallbackIngress", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+func getDesiredIngress(client client.Client, instance *operatorsv1beta1.CommonWebUI, host string) (*netv1.Ingress, error) {
+	reqLogger := log.WithValues("func", "getDesiredIngress", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 
-	metaLabels := LabelsForMetadata(CallbackIngressName)
-	pathType := netv1.PathType("ImplementationSpecific")
+	config := instance.Spec.Ingress
+	pathType := netv1.PathTypePrefix
 
 	ingress := &netv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        CallbackIngressName,
-			Annotations: CallbackIngressAnnotations,
-			Labels:      metaLabels,
+			Name:        CnIngressName,
 			Namespace:   instance.Namespace,
+			Labels:      MergeMap(LabelsForMetadata(CnIngressName), instance.Spec.Labels),
+			Annotations: MergeMap(MergeMap(nil, CnIngressAnnotations), config.Annotations),
 		},
 		Spec: netv1.IngressSpec{
+			TLS: []netv1.IngressTLS{
+				{
+					Hosts:      []string{host},
+					SecretName: config.TLSSecretName,
+				},
+			},
 			Rules: []netv1.IngressRule{
 				{
+					Host: host,
 					IngressRuleValue: netv1.IngressRuleValue{
 						HTTP: &netv1.HTTPIngressRuleValue{
 							Paths: []netv1.HTTPIngressPath{
 								{
-									Path:     "/auth/liberty/callback",
+									Path:     CnRoutePath,
 									PathType: &pathType,
 									Backend: netv1.IngressBackend{
 										Service: &netv1.IngressServiceBackend{
-											Name: instance.Spec.CommonWebUIConfig.ServiceName,
+											Name: ServiceName,
 											Port: netv1.ServiceBackendPort{
 												Number: 3000,
 											},
@@ -240,137 +137,71 @@ func getDesiredCallbackIngress(client client.Client, instance *operatorsv1alpha1
 			},
 		},
 	}
+	if config.IngressClassName != "" {
+		ingress.Spec.IngressClassName = &config.IngressClassName
+	}
 
 	err := controllerutil.SetControllerReference(instance, ingress, client.Scheme())
 	if err != nil {
-		reqLogger.Error(err, "Failed to set owner for callback ingress")
+		reqLogger.Error(err, "Failed to set owner for ingress")
 		return nil, err
 	}
 
 	return ingress, nil
 }
 
-func ReconcileCallbackIngress(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileCallbackIngress", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling callback ingress")
+// ingressResource is the cp-console Ingress on CNCF clusters, it only exists when the ingress is enabled in the CR
+type ingressResource struct{}
 
-	desiredIngress, err := getDesiredCallbackIngress(client, instance)
-	if err != nil {
-		return err
+func (ingressResource) Name() string {
+	return "Ingress"
+}
+
+func (ingressResource) FailedReason(err error) string {
+	if errorf.Is(err, ErrClusterAddressMissing) {
+		return operatorsv1beta1.ReasonClusterInfoMissing
 	}
+	return operatorsv1beta1.ReasonIngressFailed
+}
 
-	return reconcileIngress(ctx, client, instance, CallbackIngressName, desiredIngress, needToRequeue)
+func (ingressResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return GetExposureMode(rc) == operatorsv1beta1.ExposureModeIngress
 }
 
-func getDesiredNavIngress(client client.Client, instance *operatorsv1alpha1.CommonWebUI) (*netv1.Ingress, error) {
-	reqLogger := log.WithValues("func", "getDesiredNavIngress", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+func (ingressResource) Object(rc *ReconcileContext) client.Object {
+	return &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: CnIngressName, Namespace: rc.Instance.Namespace}}
+}
 
-	metaLabels := LabelsForMetadata(NavIngressName)
-	pathType := netv1.PathType("ImplementationSpecific")
+func (ingressResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	instance := rc.Instance
+	reqLogger := log.WithValues("func", "ingressResource.Desired", "namespace", instance.Namespace)
 
-	ingress := &netv1.Ingress{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        NavIngressName,
-			Annotations: NavIngressAnnotations,
-			Labels:      metaLabels,
-			Namespace:   instance.Namespace,
-		},
-		Spec: netv1.IngressSpec{
-			Rules: []netv1.IngressRule{
-				{
-					IngressRuleValue: netv1.IngressRuleValue{
-						HTTP: &netv1.HTTPIngressRuleValue{
-							Paths: []netv1.HTTPIngressPath{
-								{
-									Path:     instance.Spec.CommonWebUIConfig.IngressPath,
-									PathType: &pathType,
-									Backend: netv1.IngressBackend{
-										Service: &netv1.IngressServiceBackend{
-											Name: instance.Spec.CommonWebUIConfig.ServiceName,
-											Port: netv1.ServiceBackendPort{
-												Number: 3000,
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
+	// Check if operator has required Ingress permissions in the instance namespace
+	ingressVerbs := []string{"get", "list", "watch", "create", "delete", "update", "patch"}
+	hasIngressAccess, err := HasAPIAccess(ctx, rc.Client, instance.Namespace, "networking.k8s.io", "ingresses", ingressVerbs)
+	if err != nil {
+		reqLogger.Error(err, "Failed to check Ingress permissions; skipping Ingress reconciliation")
+		return nil, nil
+	}
+	if !hasIngressAccess {
+		reqLogger.Info("Operator does not have required Ingress permissions; skipping Ingress reconciliation")
+		return nil, nil
 	}
 
-	err := controllerutil.SetControllerReference(instance, ingress, client.Scheme())
+	host, err := getClusterAddress(ctx, rc.Client, instance.Namespace)
 	if err != nil {
-		reqLogger.Error(err, "Failed to set owner for common-nav ingress")
 		return nil, err
 	}
 
-	return ingress, nil
+	return getDesiredIngress(rc.Client, instance, host)
 }
 
-func ReconcileNavIngress(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileNavIngress", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling common-nav ingress")
-
-	desiredIngress, err := getDesiredNavIngress(client, instance)
-	if err != nil {
-		return err
-	}
-
-	return reconcileIngress(ctx, client, instance, NavIngressName, desiredIngress, needToRequeue)
+func (ingressResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
 }
 
-// nolint
-func reconcileIngress(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, ingressName string, desiredIngress *netv1.Ingress, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileIngress", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-
-	ingress := &netv1.Ingress{}
-
-	err := client.Get(ctx, types.NamespacedName{Name: ingressName, Namespace: instance.Namespace}, ingress)
-	if err != nil && !errors.IsNotFound(err) {
-		reqLogger.Error(err, "Failed to get ingress", "Ingress.Namespace", instance.Namespace, "Ingress.Name", ingressName)
-		return err
+func (ingressResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return []operatorsv1beta1.ManagedResourceStatus{
+		getIngressStatus(ctx, rc.Client, types.NamespacedName{Name: CnIngressName, Namespace: rc.Instance.Namespace}),
 	}
-
-	if err != nil && errors.IsNotFound(err) {
-		reqLogger.Info("Creating a new ingress", "Ingress.Namespace", desiredIngress.Namespace, "Ingress.Name", desiredIngress.Name)
-
-		err = client.Create(ctx, desiredIngress)
-		if err != nil {
-			if errors.IsAlreadyExists(err) {
-				// Ingress already exists from a previous reconcile
-				reqLogger.Info("Ingress already exists")
-				*needToRequeue = true
-			} else {
-				// Failed to create a new ingress
-				reqLogger.Info("Failed to create a new ingress", "Ingress.Namespace", desiredIngress.Namespace, "Ingress.Name", desiredIngress.Name)
-				return err
-			}
-		} else {
-			// Requeue after creating new ingress
-			*needToRequeue = true
-		}
-	} else {
-		// Determine if current ingress has changed
-		reqLogger.Info("Comparing current and desired ingresses")
-
-		if !IsIngressEqual(ingress, desiredIngress) {
-			reqLogger.Info("Updating ingress", "Ingress.Namespace", ingress.Namespace, "Ingress.Name", ingress.Name)
-
-			ingress.ObjectMeta.Name = desiredIngress.ObjectMeta.Name
-			ingress.ObjectMeta.Labels = desiredIngress.ObjectMeta.Labels
-			ingress.ObjectMeta.Annotations = desiredIngress.ObjectMeta.Annotations
-			ingress.Spec = desiredIngress.Spec
-
-			err = client.Update(ctx, ingress)
-			if err != nil {
-				reqLogger.Error(err, "Failed to update ingress", "Ingress.Namespace", ingress.Namespace, "Ingress.Name", ingress.Name)
-				return err
-			}
-		}
-	}
-
-	return nil
 }
@@ -0,0 +1,336 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// ReconcileContext holds what the managed resources need to reconcile a CommonWebUI
+type ReconcileContext struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Instance *operatorsv1beta1.CommonWebUI
+	IsCncf   bool
+	IsZen    bool
+
+	//Reads the objects that are not in the cache of the manager, the Client is used when it is not set
+	APIReader client.Reader
+
+	//How long to wait for the certificate secret before the CR is marked degraded
+	CertWaitTimeout time.Duration
+
+	//How long until the CR has to be reconciled again without a change, for example to rotate a certificate
+	RequeueAfter time.Duration
+}
+
+// Reader returns the reader of the secrets that are referenced by name in the CR, the cache of the
+// operator only holds the common-web-ui-cert secret
+func (rc *ReconcileContext) Reader() client.Reader {
+	if rc.APIReader != nil {
+		return rc.APIReader
+	}
+	return rc.Client
+}
+
+// RequeueWithin makes the CR reconcile again within the interval at the latest
+func (rc *ReconcileContext) RequeueWithin(interval time.Duration) {
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+	if rc.RequeueAfter == 0 || interval < rc.RequeueAfter {
+		rc.RequeueAfter = interval
+	}
+}
+
+// ManagedResource is a component of the common-web-ui operand that is managed for a CommonWebUI. The
+// controller reconciles the components of its registry in order, the desired state is server-side applied
+// so there is no comparison with the existing object.
+type ManagedResource interface {
+	// Name identifies the component in the logs, metrics and reconcile results
+	Name() string
+
+	// FailedReason is the condition reason that is reported when the component fails to reconcile
+	FailedReason(err error) string
+
+	// Enabled returns false when the component is not managed for the CR or on the platform, an
+	// existing object of a disabled component is deleted
+	Enabled(ctx context.Context, rc *ReconcileContext) bool
+
+	// Object returns an empty object of the component with its name and namespace set
+	Object(rc *ReconcileContext) client.Object
+
+	// Desired returns the state of the component to apply, or nil when it cannot be applied right now
+	// and the existing object is left as it is
+	Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error)
+
+	// Mutate carries the state that is owned by others over from the existing object, which is nil when
+	// the object does not exist yet. It returns true when an immutable field has changed and the existing
+	// object has to be recreated.
+	Mutate(rc *ReconcileContext, existing, desired client.Object) bool
+
+	// Status returns the status of the objects of the component for the CR status, or nil when they
+	// are not reported
+	Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus
+}
+
+// Gate is implemented by the components that can only be applied once a prerequisite is met. The
+// reconcile stops at the component and is requeued after the returned interval until then.
+type Gate interface {
+	WaitFor(ctx context.Context, rc *ReconcileContext) (time.Duration, error)
+}
+
+// Generated is implemented by the components whose desired state is generated by the operator from the
+// existing object, like the self-signed certificates. Their objects only change when the operator renews
+// them, so the changes are not reported as drift.
+type Generated interface {
+	Generated() bool
+}
+
+// Operations reported for the components in addition to the controllerutil results
+const OperationResultDeleted controllerutil.OperationResult = "deleted"
+const OperationResultDisabled controllerutil.OperationResult = "disabled"
+const OperationResultSkipped controllerutil.OperationResult = "skipped"
+const OperationResultWaiting controllerutil.OperationResult = "waiting"
+const OperationResultUnmanaged controllerutil.OperationResult = "unmanaged"
+
+// ComponentResult is the outcome of the reconcile of one component
+type ComponentResult struct {
+	Name         string
+	Operation    controllerutil.OperationResult
+	RequeueAfter time.Duration
+
+	//The fields of the existing object that the apply changed, set when the operation is updated
+	Drift *operatorsv1beta1.ResourceDrift
+}
+
+// Registry is the ordered list of components that are reconciled for a CommonWebUI
+type Registry struct {
+	resources []ManagedResource
+}
+
+// NewRegistry returns a registry with the components in the order they are reconciled
+func NewRegistry(resources ...ManagedResource) *Registry {
+	return &Registry{resources: resources}
+}
+
+// DefaultRegistry returns the components of the common-web-ui operand. The configmaps, service account
+// and certificates come first, the deployment waits for the certificate secret.
+func DefaultRegistry() *Registry {
+	return NewRegistry(
+		log4jsConfigMapResource{},
+		commonWebUIConfigMapResource{},
+		serviceAccountResource{},
+		selfSignedCAResource{},
+		selfSignedCertificateResource{},
+		certificateResource{},
+		deploymentResource{},
+		serviceResource{},
+		routeResource{},
+		ingressResource{},
+		gatewayCAConfigMapResource{},
+		httpRouteResource{},
+		backendTLSPolicyResource{},
+		horizontalPodAutoscalerResource{},
+		podDisruptionBudgetResource{},
+		networkPolicyResource{},
+	)
+}
+
+// Register adds a component at the end of the registry
+func (r *Registry) Register(resource ManagedResource) {
+	r.resources = append(r.resources, resource)
+}
+
+// Resources returns the components in the order they are reconciled
+func (r *Registry) Resources() []ManagedResource {
+	return r.resources
+}
+
+// ReconcileComponent applies the desired state of a component, or deletes its object when the
+// component is disabled
+func ReconcileComponent(ctx context.Context, rc *ReconcileContext, resource ManagedResource) (ComponentResult, error) {
+	reqLogger := log.WithValues("func", "ReconcileComponent", "component", resource.Name(), "instance.Name", rc.Instance.Name,
+		"instance.Namespace", rc.Instance.Namespace)
+
+	result := ComponentResult{Name: resource.Name(), Operation: controllerutil.OperationResultNone}
+
+	existing := resource.Object(rc)
+	getExisting := func() (bool, error) {
+		err := rc.Client.Get(ctx, client.ObjectKeyFromObject(existing), existing)
+		if err == nil {
+			return true, nil
+		}
+		//The kind is not served on this platform, so there is nothing to read
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	exists, err := getExisting()
+
+	//An object with the unmanaged annotation is neither changed nor deleted
+	if err == nil && exists && IsUnmanaged(existing) {
+		reqLogger.Info("Object is unmanaged - skipping", "Name", existing.GetName())
+		result.Operation = OperationResultUnmanaged
+		return result, nil
+	}
+
+	if !resource.Enabled(ctx, rc) {
+		result.Operation = OperationResultDisabled
+		if err != nil || !exists {
+			//The cleanup of a disabled component does not stop the reconcile
+			if err != nil {
+				reqLogger.Error(err, "Unable to read the object of the disabled component for deletion - deletion skipped")
+			}
+			return result, nil
+		}
+		reqLogger.Info("Component disabled - deleting", "Name", existing.GetName())
+		err = rc.Client.Delete(ctx, existing)
+		if err != nil && !errors.IsNotFound(err) {
+			reqLogger.Error(err, "Error deleting the object of the disabled component - reconciliation will proceed")
+			return result, nil
+		}
+		result.Operation = OperationResultDeleted
+		return result, nil
+	}
+
+	if err != nil {
+		reqLogger.Error(err, "Failed to get the existing object")
+		return result, err
+	}
+
+	if gate, ok := resource.(Gate); ok {
+		requeueAfter, err := gate.WaitFor(ctx, rc)
+		if err != nil {
+			return result, err
+		}
+		if requeueAfter > 0 {
+			result.Operation = OperationResultWaiting
+			result.RequeueAfter = requeueAfter
+			return result, nil
+		}
+	}
+
+	desired, err := resource.Desired(ctx, rc)
+	if err != nil {
+		return result, err
+	}
+	if desired == nil {
+		result.Operation = OperationResultSkipped
+		return result, nil
+	}
+
+	var current client.Object
+	if exists {
+		current = existing
+	}
+	if resource.Mutate(rc, current, desired) {
+		reqLogger.Info("Immutable field changed - recreating", "Name", existing.GetName())
+		err = rc.Client.Delete(ctx, existing)
+		if err != nil && !errors.IsNotFound(err) {
+			reqLogger.Error(err, "Unable to delete the existing object for recreate")
+			return result, err
+		}
+	}
+
+	var changedFields []string
+	result.Operation, changedFields, err = applyResource(ctx, rc.Client, desired)
+	if err != nil {
+		reqLogger.Error(err, "Failed to apply the desired state", "Name", desired.GetName())
+		return result, err
+	}
+	if generated, ok := resource.(Generated); ok && generated.Generated() {
+		changedFields = nil
+	}
+	if len(changedFields) > 0 {
+		kind := desired.GetObjectKind().GroupVersionKind().Kind
+		if gvk, err := apiutil.GVKForObject(desired, rc.Client.Scheme()); err == nil {
+			kind = gvk.Kind
+		}
+		result.Drift = &operatorsv1beta1.ResourceDrift{
+			Kind:       kind,
+			ObjectName: desired.GetName(),
+			Fields:     changedFields,
+		}
+	}
+	return result, nil
+}
+
+func setControllerReference(rc *ReconcileContext, obj client.Object) error {
+	return controllerutil.SetControllerReference(rc.Instance, obj, rc.Client.Scheme())
+}
+
+// GetServiceStatus returns the status of the objects of the enabled components
+func (r *Registry) GetServiceStatus(ctx context.Context, rc *ReconcileContext) operatorsv1beta1.ServiceStatus {
+	status := operatorsv1beta1.ServiceStatus{
+		ObjectName:       rc.Instance.Name,
+		Namespace:        rc.Instance.Namespace,
+		APIVersion:       rc.Instance.APIVersion,
+		Kind:             "CommonWebUI",
+		ManagedResources: []operatorsv1beta1.ManagedResourceStatus{},
+	}
+
+	for _, resource := range r.resources {
+		object := resource.Object(rc)
+		err := rc.Client.Get(ctx, client.ObjectKeyFromObject(object), object)
+		unmanaged := err == nil && IsUnmanaged(object)
+
+		if !unmanaged && !resource.Enabled(ctx, rc) {
+			continue
+		}
+		resourceStatus := resource.Status(ctx, rc)
+		if unmanaged {
+			resourceStatus = setUnmanagedStatus(rc, resourceStatus, object)
+		}
+		status.ManagedResources = append(status.ManagedResources, resourceStatus...)
+	}
+	return status
+}
+
+// setUnmanagedStatus marks the status of an unmanaged object, the object is added when the component does
+// not report a status for it
+func setUnmanagedStatus(rc *ReconcileContext, resourceStatus []operatorsv1beta1.ManagedResourceStatus,
+	object client.Object) []operatorsv1beta1.ManagedResourceStatus {
+	gvk, _ := apiutil.GVKForObject(object, rc.Client.Scheme())
+	for i := range resourceStatus {
+		if resourceStatus[i].Kind == gvk.Kind && resourceStatus[i].ObjectName == object.GetName() {
+			resourceStatus[i].Unmanaged = true
+			return resourceStatus
+		}
+	}
+	return append(resourceStatus, operatorsv1beta1.ManagedResourceStatus{
+		ObjectName: object.GetName(),
+		APIVersion: gvk.GroupVersion().String(),
+		Namespace:  object.GetNamespace(),
+		Kind:       gvk.Kind,
+		Status:     Ready,
+		Unmanaged:  true,
+	})
+}
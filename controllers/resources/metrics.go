@@ -0,0 +1,165 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// The metrics are served by the controller-runtime metrics endpoint together with its default metrics
+
+var reconcileStepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "commonwebui_reconcile_step_duration_seconds",
+	Help:    "Duration of the CommonWebUI reconcile steps",
+	Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+}, []string{"step", "result"})
+
+var managedResourceOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "commonwebui_managed_resource_operations_total",
+	Help: "Number of creates, updates, patches and deletes of the resources managed for CommonWebUI",
+}, []string{"kind", "operation"})
+
+var driftCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "commonwebui_drift_corrections_total",
+	Help: "Number of times the operator reverted changes made outside of the operator to the resources managed for CommonWebUI",
+}, []string{"kind"})
+
+var managedResourceReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "commonwebui_managed_resource_ready",
+	Help: "Status of the resources managed for a CommonWebUI, 1 when the resource is Ready and 0 otherwise",
+}, []string{"namespace", "commonwebui", "kind", "resource"})
+
+var operandInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "commonwebui_operand_info",
+	Help: "Version of the common-web-ui operand of a CommonWebUI, the value is always 1",
+}, []string{"namespace", "commonwebui", "version"})
+
+func init() {
+	metrics.Registry.MustRegister(reconcileStepDuration, managedResourceOperations, driftCorrections, managedResourceReady,
+		operandInfo)
+}
+
+// TimeReconcileStep runs a reconcile step and records its duration and result
+func TimeReconcileStep(step string, reconcileStep func() error) error {
+	start := time.Now()
+	err := reconcileStep()
+
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	reconcileStepDuration.WithLabelValues(step, result).Observe(time.Since(start).Seconds())
+	return err
+}
+
+// RecordServiceStatusMetrics sets the ready gauge for each managed resource and the operand version
+// of the CR. Series of resources that are no longer managed are removed.
+func RecordServiceStatusMetrics(instance *operatorsv1beta1.CommonWebUI, serviceStatus operatorsv1beta1.ServiceStatus) {
+	DeleteServiceStatusMetrics(instance.Namespace, instance.Name)
+
+	for _, managedResourceStatus := range serviceStatus.ManagedResources {
+		ready := 0.0
+		if managedResourceStatus.Status == Ready {
+			ready = 1
+		}
+		managedResourceReady.WithLabelValues(instance.Namespace, instance.Name, managedResourceStatus.Kind,
+			managedResourceStatus.ObjectName).Set(ready)
+	}
+
+	if instance.Status.OperandVersion != "" {
+		operandInfo.WithLabelValues(instance.Namespace, instance.Name, instance.Status.OperandVersion).Set(1)
+	}
+}
+
+// DeleteServiceStatusMetrics removes the status series of a CR, it is called when the CR is deleted
+func DeleteServiceStatusMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "commonwebui": name}
+	managedResourceReady.DeletePartialMatch(labels)
+	operandInfo.DeletePartialMatch(labels)
+}
+
+// MetricsClient is a client that counts the creates, updates and deletes per kind
+type MetricsClient struct {
+	client.Client
+}
+
+// NewMetricsClient wraps the client so that its write operations are counted
+func NewMetricsClient(c client.Client) client.Client {
+	return &MetricsClient{Client: c}
+}
+
+func (c *MetricsClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	if err == nil {
+		c.countOperation(obj, "create")
+	}
+	return err
+}
+
+func (c *MetricsClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := c.Client.Update(ctx, obj, opts...)
+	if err == nil {
+		c.countOperation(obj, "update")
+	}
+	return err
+}
+
+func (c *MetricsClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	//Applies are counted by observeApply once it is known whether they changed anything
+	if err == nil && patch.Type() != types.ApplyPatchType {
+		c.countOperation(obj, "patch")
+	}
+	return err
+}
+
+func (c *MetricsClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	if err == nil {
+		c.countOperation(obj, "delete")
+	}
+	return err
+}
+
+func (c *MetricsClient) observeApply(obj client.Object, result controllerutil.OperationResult) {
+	if result == controllerutil.OperationResultCreated {
+		c.countOperation(obj, "create")
+	} else {
+		c.countOperation(obj, "update")
+	}
+	if observer, ok := c.Client.(applyObserver); ok {
+		observer.observeApply(obj, result)
+	}
+}
+
+func (c *MetricsClient) countOperation(obj client.Object, operation string) {
+	kind := "Unknown"
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	managedResourceOperations.WithLabelValues(kind, operation).Inc()
+}
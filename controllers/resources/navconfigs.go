@@ -27,17 +27,17 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 )
 
-func ReconcileAdminHubNavConfig(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI) error {
+func ReconcileAdminHubNavConfig(ctx context.Context, client client.Client, instance *operatorsv1beta1.CommonWebUI) error {
 	reqLogger := log.WithValues("func", "reconcileAdminHubNavConfig", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 	reqLogger.Info("Reconciling admin hub nav config")
 
 	return reconcileNavConfig(ctx, client, instance, AdminHubNavConfigName, AdminHubNavConfig)
 }
 
-func reconcileNavConfig(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, name, config string) error {
+func reconcileNavConfig(ctx context.Context, client client.Client, instance *operatorsv1beta1.CommonWebUI, name, config string) error {
 	reqLogger := log.WithValues("func", "reconcileNavConfig", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 
 	var template map[string]interface{}
@@ -0,0 +1,164 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// IsNetworkPolicyEnabled returns true if the managed NetworkPolicy is turned on in the CR
+func IsNetworkPolicyEnabled(instance *operatorsv1beta1.CommonWebUI) bool {
+	return instance.Spec.NetworkPolicy != nil && instance.Spec.NetworkPolicy.Enabled
+}
+
+func namespacePeer(namespace string) netv1.NetworkPolicyPeer {
+	return netv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{corev1.LabelMetadataName: namespace},
+		},
+	}
+}
+
+func getDesiredNetworkPolicy(rc *ReconcileContext) (*netv1.NetworkPolicy, error) {
+	instance := rc.Instance
+	reqLogger := log.WithValues("func", "getDesiredNetworkPolicy", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	config := instance.Spec.NetworkPolicy
+
+	var peers []netv1.NetworkPolicyPeer
+
+	//Allow the router (OpenShift) or the ingress controller (CNCF) that exposes the console
+	if rc.IsCncf {
+		peers = append(peers, namespacePeer(GetStringWithDefault(config.IngressControllerNamespace, DefaultIngressControllerNamespace)))
+	} else {
+		peers = append(peers, netv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{OpenShiftIngressPolicyGroupLabel: "ingress"},
+			},
+		})
+	}
+
+	//With the Gateway exposure mode the gateway data plane connects to the pods, it runs in the namespace
+	//of the Gateway unless another namespace is set
+	if GetExposureMode(rc) == operatorsv1beta1.ExposureModeGateway && isGatewayConfigured(instance) {
+		gatewayNamespace := GetStringWithDefault(instance.Spec.Exposure.Gateway.ParentRef.Namespace, instance.Namespace)
+		peers = append(peers, namespacePeer(GetStringWithDefault(config.GatewayNamespace, gatewayNamespace)))
+	}
+
+	//Allow the namespace of the CR and the watched namespaces, sorted so the policy does not change
+	//with the order of WATCH_NAMESPACE
+	namespaces := map[string]bool{instance.Namespace: true}
+	for _, namespace := range strings.Split(os.Getenv("WATCH_NAMESPACE"), ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces[namespace] = true
+		}
+	}
+	sortedNamespaces := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		sortedNamespaces = append(sortedNamespaces, namespace)
+	}
+	sort.Strings(sortedNamespaces)
+	for _, namespace := range sortedNamespaces {
+		peers = append(peers, namespacePeer(namespace))
+	}
+
+	for _, peer := range config.AdditionalPeers {
+		peers = append(peers, *peer.DeepCopy())
+	}
+
+	protocol := corev1.ProtocolTCP
+	port := intstr.FromInt(3000)
+
+	metaLabels := MergeMap(LabelsForMetadata(NetworkPolicyName), instance.Spec.Labels)
+	networkPolicy := &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyName,
+			Namespace: instance.Namespace,
+			Labels:    metaLabels,
+		},
+		Spec: netv1.NetworkPolicySpec{
+			//Use the deployment selector labels so the policy always matches the pods
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: LabelsForSelector(DeploymentName, CommonWebUICRType, instance.Name),
+			},
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+			Ingress: []netv1.NetworkPolicyIngressRule{
+				{
+					Ports: []netv1.NetworkPolicyPort{
+						{
+							Protocol: &protocol,
+							Port:     &port,
+						},
+					},
+					From: peers,
+				},
+			},
+		},
+	}
+
+	err := controllerutil.SetControllerReference(instance, networkPolicy, rc.Client.Scheme())
+	if err != nil {
+		reqLogger.Error(err, "Failed to set owner for network policy")
+		return nil, err
+	}
+
+	return networkPolicy, nil
+}
+
+// networkPolicyResource restricts the traffic to the common-web-ui pods, it only exists when the network
+// policy is enabled in the CR
+type networkPolicyResource struct{}
+
+func (networkPolicyResource) Name() string {
+	return "NetworkPolicy"
+}
+
+func (networkPolicyResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonNetworkPolicyFailed
+}
+
+func (networkPolicyResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return IsNetworkPolicyEnabled(rc.Instance)
+}
+
+func (networkPolicyResource) Object(rc *ReconcileContext) client.Object {
+	return &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: NetworkPolicyName, Namespace: rc.Instance.Namespace}}
+}
+
+func (networkPolicyResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	return getDesiredNetworkPolicy(rc)
+}
+
+func (networkPolicyResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
+
+func (networkPolicyResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return nil
+}
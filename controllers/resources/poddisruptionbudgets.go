@@ -0,0 +1,149 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// IsPodDisruptionBudgetRequired returns true if a PodDisruptionBudget should exist for the CR. The
+// budget is only created when at least two replicas run, when the HPA is enabled its minimum is used.
+func IsPodDisruptionBudgetRequired(instance *operatorsv1beta1.CommonWebUI) bool {
+	config := instance.Spec.PodDisruptionBudget
+	if config != nil && config.Enabled != nil && !*config.Enabled {
+		return false
+	}
+
+	return getPodDisruptionBudgetReplicas(instance) > 1
+}
+
+// getPodDisruptionBudgetReplicas returns the number of replicas the budget is computed for, which is the
+// HPA minimum when autoscaling is on
+func getPodDisruptionBudgetReplicas(instance *operatorsv1beta1.CommonWebUI) int32 {
+	if autoScaling := GetEffectiveAutoScaling(instance); autoScaling != nil {
+		return autoScaling.MinReplicas
+	}
+	return GetEffectiveSpec(instance).Replicas
+}
+
+// SetPodDisruptionBudgetCondition reports in the CR status and with a warning event that a budget enabled in the
+// CR is not created because a single replica runs. The condition is removed once the budget can be created.
+func SetPodDisruptionBudgetCondition(recorder record.EventRecorder, instance *operatorsv1beta1.CommonWebUI) {
+	config := instance.Spec.PodDisruptionBudget
+	if config == nil || config.Enabled == nil || !*config.Enabled || IsPodDisruptionBudgetRequired(instance) {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, operatorsv1beta1.ConditionPodDisruptionBudgetSkipped)
+		return
+	}
+
+	message := fmt.Sprintf("The PodDisruptionBudget is not created for %d replica, it would block node drains",
+		getPodDisruptionBudgetReplicas(instance))
+	if !meta.IsStatusConditionTrue(instance.Status.Conditions, operatorsv1beta1.ConditionPodDisruptionBudgetSkipped) && recorder != nil {
+		recorder.Event(instance, corev1.EventTypeWarning, operatorsv1beta1.ReasonSingleReplica, message)
+	}
+	SetCondition(instance, operatorsv1beta1.ConditionPodDisruptionBudgetSkipped, metav1.ConditionTrue, operatorsv1beta1.ReasonSingleReplica, message)
+}
+
+func getDesiredPodDisruptionBudget(client client.Client, instance *operatorsv1beta1.CommonWebUI) (*policyv1.PodDisruptionBudget, error) {
+	reqLogger := log.WithValues("func", "getDesiredPodDisruptionBudget", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	//Allow up to half of the pods to be evicted at a time, at least one. With the HPA the budget follows its
+	//minimum, so scaling up does not change the budget.
+	maxUnavailable := intstr.FromInt(int(getPodDisruptionBudgetReplicas(instance) / 2))
+	if maxUnavailable.IntValue() < 1 {
+		maxUnavailable = intstr.FromInt(1)
+	}
+	spec := policyv1.PodDisruptionBudgetSpec{
+		MaxUnavailable: &maxUnavailable,
+		Selector: &metav1.LabelSelector{
+			MatchLabels: LabelsForSelector(DeploymentName, CommonWebUICRType, instance.Name),
+		},
+	}
+
+	if config := instance.Spec.PodDisruptionBudget; config != nil {
+		if config.MinAvailable != nil {
+			spec.MinAvailable = config.MinAvailable
+			spec.MaxUnavailable = nil
+		} else if config.MaxUnavailable != nil {
+			spec.MaxUnavailable = config.MaxUnavailable
+		}
+	}
+
+	metaLabels := MergeMap(LabelsForMetadata(PDBName), instance.Spec.Labels)
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PDBName,
+			Namespace: instance.Namespace,
+			Labels:    metaLabels,
+		},
+		Spec: spec,
+	}
+
+	err := controllerutil.SetControllerReference(instance, pdb, client.Scheme())
+	if err != nil {
+		reqLogger.Error(err, "Failed to set owner for PDB")
+		return nil, err
+	}
+
+	return pdb, nil
+}
+
+// podDisruptionBudgetResource keeps the pods available during node drains, it only exists when more
+// than one replica runs
+type podDisruptionBudgetResource struct{}
+
+func (podDisruptionBudgetResource) Name() string {
+	return "PodDisruptionBudget"
+}
+
+func (podDisruptionBudgetResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonPDBFailed
+}
+
+func (podDisruptionBudgetResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return IsPodDisruptionBudgetRequired(rc.Instance)
+}
+
+func (podDisruptionBudgetResource) Object(rc *ReconcileContext) client.Object {
+	return &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: PDBName, Namespace: rc.Instance.Namespace}}
+}
+
+func (podDisruptionBudgetResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	return getDesiredPodDisruptionBudget(rc.Client, rc.Instance)
+}
+
+func (podDisruptionBudgetResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
+
+func (podDisruptionBudgetResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return []operatorsv1beta1.ManagedResourceStatus{
+		getPodDisruptionBudgetStatus(ctx, rc.Client, types.NamespacedName{Name: PDBName, Namespace: rc.Instance.Namespace}),
+	}
+}
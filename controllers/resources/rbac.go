@@ -15,24 +15,21 @@ package resources
 
 import (
 	"context"
-	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 )
 
 const ServiceAccountName = "ibm-commonui-operand"
 const OperandRoleName = "ibm-commonui-operand"
 const OperandRoleBindingName = "ibm-commonui-operand"
 
-func getDesiredServiceAccount(client client.Client, instance *operatorsv1alpha1.CommonWebUI) (*corev1.ServiceAccount, error) {
+func getDesiredServiceAccount(client client.Client, instance *operatorsv1beta1.CommonWebUI) (*corev1.ServiceAccount, error) {
 	reqLogger := log.WithValues("func", "getDesiredServiceAccount", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 
 	automountServiceAccountToken := false
@@ -58,87 +55,38 @@ func getDesiredServiceAccount(client client.Client, instance *operatorsv1alpha1.
 	return serviceAccount, nil
 }
 
-func ReconcileServiceAccount(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileServiceAccount", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling service account")
+// serviceAccountResource is the service account of the common-web-ui pods
+type serviceAccountResource struct{}
 
-	serviceAccount := &corev1.ServiceAccount{}
+func (serviceAccountResource) Name() string {
+	return "ServiceAccount"
+}
 
-	desiredSA, desiredErr := getDesiredServiceAccount(client, instance)
-	if desiredErr != nil {
-		return desiredErr
-	}
+func (serviceAccountResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonServiceAccountFailed
+}
 
-	err := client.Get(ctx, types.NamespacedName{Name: ServiceAccountName, Namespace: desiredSA.Namespace}, serviceAccount)
-
-	if err != nil && errors.IsNotFound(err) {
-		reqLogger.Info("Creating a new service account", "SA.Namespace", desiredSA.Namespace, "SA.Name", desiredSA.Name)
-
-		err = client.Create(ctx, desiredSA)
-		if err != nil {
-			if errors.IsAlreadyExists(err) {
-				// SA already exists from a previous reconcile
-				reqLogger.Info("Service account already exists")
-				*needToRequeue = true
-			} else {
-				// Failed to create a new SA
-				reqLogger.Info("Failed to create a new service account", "SA.Namespace", desiredSA.Namespace, "SA.Name", desiredSA.Name)
-				return err
-			}
-		} else {
-			// Requeue after creating new service account
-			*needToRequeue = true
-		}
-	} else if err != nil && !errors.IsNotFound(err) {
-		reqLogger.Error(err, "Failed to get service account", "SA.Namespace", desiredSA.Namespace, "SA.Name", ServiceAccountName)
-		return err
-	} else {
-		// Determine if current service account has changed
-		reqLogger.Info("Comparing current and desired service accounts")
-
-		if !IsServiceAccountEqual(serviceAccount, desiredSA) {
-			reqLogger.Info("Service account has changed", "SA.Namespace", desiredSA.Namespace, "SA.Name", desiredSA.Name)
-
-			// Check if AutomountServiceAccountToken has changed - this field is immutable
-			if !reflect.DeepEqual(serviceAccount.AutomountServiceAccountToken, desiredSA.AutomountServiceAccountToken) {
-				reqLogger.Info("AutomountServiceAccountToken has changed, recreating service account",
-					"old", serviceAccount.AutomountServiceAccountToken,
-					"new", desiredSA.AutomountServiceAccountToken)
-
-				// Delete the existing service account
-				err = client.Delete(ctx, serviceAccount)
-				if err != nil {
-					reqLogger.Error(err, "Failed to delete service account for recreation", "SA.Namespace", desiredSA.Namespace, "SA.Name", desiredSA.Name)
-					return err
-				}
-
-				// Create the new service account
-				err = client.Create(ctx, desiredSA)
-				if err != nil {
-					reqLogger.Error(err, "Failed to recreate service account", "SA.Namespace", desiredSA.Namespace, "SA.Name", desiredSA.Name)
-					return err
-				}
-				*needToRequeue = true
-			} else {
-				// Update other fields (labels, etc.)
-				reqLogger.Info("Updating service account metadata", "SA.Namespace", desiredSA.Namespace, "SA.Name", desiredSA.Name)
-
-				serviceAccount.ObjectMeta.Name = desiredSA.ObjectMeta.Name
-				serviceAccount.ObjectMeta.Labels = desiredSA.ObjectMeta.Labels
-
-				err = client.Update(ctx, serviceAccount)
-				if err != nil {
-					reqLogger.Error(err, "Failed to update service account", "SA.Namespace", desiredSA.Namespace, "SA.Name", desiredSA.Name)
-					return err
-				}
-			}
-		}
-	}
+func (serviceAccountResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return true
+}
+
+func (serviceAccountResource) Object(rc *ReconcileContext) client.Object {
+	return &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: ServiceAccountName, Namespace: rc.Instance.Namespace}}
+}
 
+func (serviceAccountResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	return getDesiredServiceAccount(rc.Client, rc.Instance)
+}
+
+func (serviceAccountResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
+
+func (serviceAccountResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
 	return nil
 }
 
-func getDesiredRole(client client.Client, instance *operatorsv1alpha1.CommonWebUI) (*rbacv1.Role, error) {
+func getDesiredRole(client client.Client, instance *operatorsv1beta1.CommonWebUI) (*rbacv1.Role, error) {
 	reqLogger := log.WithValues("func", "getDesiredRole", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 
 	role := &rbacv1.Role{
@@ -179,63 +127,29 @@ func getDesiredRole(client client.Client, instance *operatorsv1alpha1.CommonWebU
 	return role, nil
 }
 
-func ReconcileRole(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
+func ReconcileRole(ctx context.Context, client client.Client, instance *operatorsv1beta1.CommonWebUI, needToRequeue *bool) error {
 	reqLogger := log.WithValues("func", "reconcileRole", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 	reqLogger.Info("Reconciling role")
 
-	role := &rbacv1.Role{}
-
 	desiredRole, desiredErr := getDesiredRole(client, instance)
 	if desiredErr != nil {
 		return desiredErr
 	}
 
-	err := client.Get(ctx, types.NamespacedName{Name: desiredRole.Name, Namespace: desiredRole.Namespace}, role)
-
-	if err != nil && errors.IsNotFound(err) {
-		reqLogger.Info("Creating a new role", "Role.Namespace", desiredRole.Namespace, "Role.Name", desiredRole.Name)
-
-		err = client.Create(ctx, desiredRole)
-		if err != nil {
-			if errors.IsAlreadyExists(err) {
-				// role already exists from a previous reconcile
-				reqLogger.Info("Role already exists")
-				*needToRequeue = true
-			} else {
-				// Failed to create a new role
-				reqLogger.Info("Failed to create a new role", "Role.Namespace", desiredRole.Namespace, "Role.Name", desiredRole.Name)
-				return err
-			}
-		} else {
-			// Requeue after creating new role
-			*needToRequeue = true
-		}
-	} else if err != nil && !errors.IsNotFound(err) {
-		reqLogger.Error(err, "Failed to get role", "Role.Namespace", desiredRole.Namespace, "Role.Name", desiredRole.Name)
+	result, err := ApplyResource(ctx, client, desiredRole)
+	if err != nil {
+		reqLogger.Error(err, "Failed to apply role", "Role.Namespace", desiredRole.Namespace, "Role.Name", desiredRole.Name)
 		return err
-	} else {
-		// Determine if current role has changed
-		reqLogger.Info("Comparing current and desired role")
-
-		if !IsRoleEqual(role, desiredRole) {
-			reqLogger.Info("Updating role", "Role.Namespace", desiredRole.Namespace, "Role.Name", desiredRole.Name)
-
-			role.ObjectMeta.Name = desiredRole.ObjectMeta.Name
-			role.ObjectMeta.Labels = desiredRole.ObjectMeta.Annotations
-			role.Rules = desiredRole.Rules
-
-			err = client.Update(ctx, role)
-			if err != nil {
-				reqLogger.Error(err, "Failed to update role", "Role.Namespace", desiredRole.Namespace, "Role.Name", desiredRole.Name)
-				return err
-			}
-		}
+	}
+	if result == controllerutil.OperationResultCreated {
+		// Requeue after creating new role
+		*needToRequeue = true
 	}
 
 	return nil
 }
 
-func getDesiredRoleBinding(client client.Client, instance *operatorsv1alpha1.CommonWebUI) (*rbacv1.RoleBinding, error) {
+func getDesiredRoleBinding(client client.Client, instance *operatorsv1beta1.CommonWebUI) (*rbacv1.RoleBinding, error) {
 	reqLogger := log.WithValues("func", "getDesiredRoleBinding", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 
 	roleBinding := &rbacv1.RoleBinding{
@@ -271,58 +185,23 @@ func getDesiredRoleBinding(client client.Client, instance *operatorsv1alpha1.Com
 	return roleBinding, nil
 }
 
-func ReconcileRoleBinding(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
+func ReconcileRoleBinding(ctx context.Context, client client.Client, instance *operatorsv1beta1.CommonWebUI, needToRequeue *bool) error {
 	reqLogger := log.WithValues("func", "reconcileRoleBinding", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 	reqLogger.Info("Reconciling rolebinding")
 
-	roleBinding := &rbacv1.RoleBinding{}
-
 	desiredRoleBinding, desiredErr := getDesiredRoleBinding(client, instance)
 	if desiredErr != nil {
 		return desiredErr
 	}
 
-	err := client.Get(ctx, types.NamespacedName{Name: desiredRoleBinding.Name, Namespace: desiredRoleBinding.Namespace}, roleBinding)
-
-	if err != nil && errors.IsNotFound(err) {
-		reqLogger.Info("Creating a new role binding", "RoleBinding.Namespace", desiredRoleBinding.Namespace, "RoleBinding.Name", desiredRoleBinding.Name)
-
-		err = client.Create(ctx, desiredRoleBinding)
-		if err != nil {
-			if errors.IsAlreadyExists(err) {
-				// role binding already exists from a previous reconcile
-				reqLogger.Info("Role binding already exists")
-				*needToRequeue = true
-			} else {
-				// Failed to create a new role binding
-				reqLogger.Info("Failed to create a new role binding", "RoleBinding.Namespace", desiredRoleBinding.Namespace, "RoleBinding.Name", desiredRoleBinding.Name)
-				return err
-			}
-		} else {
-			// Requeue after creating new role binding
-			*needToRequeue = true
-		}
-	} else if err != nil && !errors.IsNotFound(err) {
-		reqLogger.Error(err, "Failed to get role binding", "RoleBinding.Namespace", desiredRoleBinding.Namespace, "RoleBinding.Name", desiredRoleBinding.Name)
+	result, err := ApplyResource(ctx, client, desiredRoleBinding)
+	if err != nil {
+		reqLogger.Error(err, "Failed to apply role binding", "RoleBinding.Namespace", desiredRoleBinding.Namespace, "RoleBinding.Name", desiredRoleBinding.Name)
 		return err
-	} else {
-		// Determine if current role binding has changed
-		reqLogger.Info("Comparing current and desired role binding")
-
-		if !IsRoleBindingEqual(roleBinding, desiredRoleBinding) {
-			reqLogger.Info("Updating role binding", "RoleBinding.Namespace", desiredRoleBinding.Namespace, "RoleBinding.Name", desiredRoleBinding.Name)
-
-			roleBinding.ObjectMeta.Name = desiredRoleBinding.ObjectMeta.Name
-			roleBinding.ObjectMeta.Labels = desiredRoleBinding.ObjectMeta.Annotations
-			roleBinding.Subjects = desiredRoleBinding.Subjects
-			roleBinding.RoleRef = desiredRoleBinding.RoleRef
-
-			err = client.Update(ctx, roleBinding)
-			if err != nil {
-				reqLogger.Error(err, "Failed to update role binding", "RoleBinding.Namespace", desiredRoleBinding.Namespace, "RoleBinding.Name", desiredRoleBinding.Name)
-				return err
-			}
-		}
+	}
+	if result == controllerutil.OperationResultCreated {
+		// Requeue after creating new role binding
+		*needToRequeue = true
 	}
 
 	return nil
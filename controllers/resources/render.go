@@ -0,0 +1,157 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"io"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// DefaultRenderClusterAddress is the stubbed cluster_address of the ibmcloud-cluster-info configmap
+const DefaultRenderClusterAddress = "cp-console.apps.example.com"
+
+// RenderOptions holds the stubbed cluster inputs that are used to render the managed resources offline
+type RenderOptions struct {
+	IsCncf bool
+
+	//The route host that is read from the ibmcloud-cluster-info configmap on a cluster
+	ClusterAddress string
+
+	//The ca.crt of the common-web-ui certificate secret, it is the route destination CA
+	CACert []byte
+}
+
+// renderClient answers the access checks of the managed resources as allowed, there is no API server
+// to ask when rendering
+type renderClient struct {
+	client.Client
+}
+
+func (c *renderClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if ssar, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+		ssar.Status.Allowed = true
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// RenderManifests returns the desired state of the resources the operator manages for the CR, in the order
+// they are reconciled. It does not contact a cluster, the cluster-info configmap and the certificate secret
+// are stubbed from the options.
+func RenderManifests(ctx context.Context, scheme *runtime.Scheme, instance *operatorsv1beta1.CommonWebUI, opts RenderOptions) ([]client.Object, error) {
+	clusterAddress := GetStringWithDefault(opts.ClusterAddress, DefaultRenderClusterAddress)
+
+	clusterInfo := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ClusterInfoConfigmapName, Namespace: instance.Namespace},
+		Data:       map[string]string{"cluster_address": clusterAddress},
+	}
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: GetCertificateSecretName(instance), Namespace: instance.Namespace},
+		Data:       map[string][]byte{"ca.crt": opts.CACert},
+	}
+
+	//The kinds of the scheme are served, so the certificate is rendered as a cert-manager certificate
+	restMapper := meta.NewDefaultRESTMapper(scheme.PreferredVersionAllGroups())
+	for gvk := range scheme.AllKnownTypes() {
+		restMapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+
+	rc := &ReconcileContext{
+		Client: &renderClient{Client: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).
+			WithObjects(instance, clusterInfo, certSecret).Build()},
+		Instance: instance,
+		IsCncf:   opts.IsCncf,
+	}
+
+	objects := []client.Object{}
+	for _, resource := range DefaultRegistry().Resources() {
+		if !resource.Enabled(ctx, rc) {
+			continue
+		}
+		desired, err := resource.Desired(ctx, rc)
+		if err != nil {
+			return nil, err
+		}
+		if desired == nil {
+			continue
+		}
+		gvk, err := apiutil.GVKForObject(desired, scheme)
+		if err != nil {
+			return nil, err
+		}
+		desired.GetObjectKind().SetGroupVersionKind(gvk)
+		objects = append(objects, desired)
+	}
+	return objects, nil
+}
+
+// WriteManifests writes the objects as a multi-document YAML stream. The empty status and the creation
+// timestamps of the objects that have not been created are left out.
+func WriteManifests(w io.Writer, objects []client.Object) error {
+	for _, obj := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		//The content of an unstructured object is not copied by the converter
+		if _, ok := obj.(runtime.Unstructured); ok {
+			content = runtime.DeepCopyJSON(content)
+		}
+		delete(content, "status")
+		removeNullCreationTimestamps(content)
+
+		out, err := yaml.Marshal(content)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(w, "---\n"+string(out)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeNullCreationTimestamps(content map[string]interface{}) {
+	for key, value := range content {
+		if key == "creationTimestamp" && value == nil {
+			delete(content, key)
+			continue
+		}
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			removeNullCreationTimestamps(typed)
+		case []interface{}:
+			for _, item := range typed {
+				if itemMap, ok := item.(map[string]interface{}); ok {
+					removeNullCreationTimestamps(itemMap)
+				}
+			}
+		}
+	}
+}
@@ -22,21 +22,23 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	"github.com/IBM/ibm-commonui-operator/api/v1beta1"
 )
 
 const Ready = "Ready"
 const NotReady = "NotReady"
 const Unknown = "Unknown"
 
-func getServiceStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName) (status v1alpha1.ManagedResourceStatus) {
+func getServiceStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName) (status v1beta1.ManagedResourceStatus) {
 	reqLogger := log.WithValues("func", "getServiceStatus", "namespacedName", namespacedName)
 
-	status = v1alpha1.ManagedResourceStatus{
+	status = v1beta1.ManagedResourceStatus{
 		ObjectName: namespacedName.Name,
 		APIVersion: Unknown,
 		Namespace:  namespacedName.Namespace,
@@ -57,10 +59,10 @@ func getServiceStatus(ctx context.Context, k8sClient client.Client, namespacedNa
 	return
 }
 
-func getDeploymentStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName) (status v1alpha1.ManagedResourceStatus) {
+func getDeploymentStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName) (status v1beta1.ManagedResourceStatus) {
 	reqLogger := log.WithValues("func", "getDeploymentStatus", "namespacedName", namespacedName)
 
-	status = v1alpha1.ManagedResourceStatus{
+	status = v1beta1.ManagedResourceStatus{
 		ObjectName: namespacedName.Name,
 		APIVersion: Unknown,
 		Namespace:  namespacedName.Namespace,
@@ -89,10 +91,10 @@ func getDeploymentStatus(ctx context.Context, k8sClient client.Client, namespace
 	return
 }
 
-func getRouteStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName) (status v1alpha1.ManagedResourceStatus) {
+func getRouteStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName) (status v1beta1.ManagedResourceStatus) {
 	reqLogger := log.WithValues("func", "getRouteStatus", "namespacedName", namespacedName)
 
-	status = v1alpha1.ManagedResourceStatus{
+	status = v1beta1.ManagedResourceStatus{
 		ObjectName: namespacedName.Name,
 		APIVersion: Unknown,
 		Namespace:  namespacedName.Namespace,
@@ -122,101 +124,56 @@ func getRouteStatus(ctx context.Context, k8sClient client.Client, namespacedName
 	return
 }
 
-type statusRetrievalFunc func(context.Context, client.Client, []string, string) []v1alpha1.ManagedResourceStatus
+// An Ingress is ready once it exists. Ingress controllers that run on node ports or the host network never
+// publish an address, so the address is only logged.
+func getIngressStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName) (status v1beta1.ManagedResourceStatus) {
+	reqLogger := log.WithValues("func", "getIngressStatus", "namespacedName", namespacedName)
 
-func getAllServiceStatus(ctx context.Context, k8sClient client.Client, names []string, namespace string) (statuses []v1alpha1.ManagedResourceStatus) {
-	reqLogger := log.WithValues("func", "getAllServiceStatus", "namespace", namespace)
-	for _, name := range names {
-		nsn := types.NamespacedName{Name: name, Namespace: namespace}
-		statuses = append(statuses, getServiceStatus(ctx, k8sClient, nsn))
+	status = v1beta1.ManagedResourceStatus{
+		ObjectName: namespacedName.Name,
+		APIVersion: Unknown,
+		Namespace:  namespacedName.Namespace,
+		Kind:       "Ingress",
+		Status:     NotReady,
 	}
-	reqLogger.Info("New statuses", "statuses", statuses)
-	return
-}
+	ingress := &netv1.Ingress{}
+	err := k8sClient.Get(ctx, namespacedName, ingress)
 
-func getAllDeploymentStatus(ctx context.Context, k8sClient client.Client, names []string, namespace string) (statuses []v1alpha1.ManagedResourceStatus) {
-	reqLogger := log.WithValues("func", "getAllDeploymentStatus", "namespace", namespace)
-	for _, name := range names {
-		nsn := types.NamespacedName{Name: name, Namespace: namespace}
-		statuses = append(statuses, getDeploymentStatus(ctx, k8sClient, nsn))
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			reqLogger.Error(err, "Error reading ingress for status update")
+		}
+		return
 	}
-	reqLogger.Info("New statuses", "statuses", statuses)
-	return
-}
 
-func getAllRouteStatus(ctx context.Context, k8sClient client.Client, names []string, namespace string) (statuses []v1alpha1.ManagedResourceStatus) {
-	reqLogger := log.WithValues("func", "getAllRouteStatus", "namespace", namespace)
-	for _, name := range names {
-		nsn := types.NamespacedName{Name: name, Namespace: namespace}
-		statuses = append(statuses, getRouteStatus(ctx, k8sClient, nsn))
+	status.APIVersion = ingress.APIVersion
+	if len(ingress.Status.LoadBalancer.Ingress) == 0 {
+		reqLogger.V(1).Info("Ingress controller has not published an address for the ingress")
 	}
-	reqLogger.Info("New statuses", "statuses", statuses)
+	status.Status = Ready
 	return
 }
 
-func GetCurrentServiceStatus(ctx context.Context, k8sClient client.Client, instance *v1alpha1.CommonWebUI, isCncf bool) (status v1alpha1.ServiceStatus) {
-	reqLogger := log.WithValues("func", "getCurrentServiceStatus", "namespace", instance.Namespace, "isCncf", isCncf)
-	type statusRetrieval struct {
-		names []string
-		f     statusRetrievalFunc
-	}
-
-	//
-	statusRetrievals := []statusRetrieval{
-		{
-			names: []string{
-				"common-web-ui",
-			},
-			f: getAllServiceStatus,
-		},
-		{
-			names: []string{
-				"common-web-ui",
-			},
-			f: getAllDeploymentStatus,
-		},
-	}
+func getPodDisruptionBudgetStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName) (status v1beta1.ManagedResourceStatus) {
+	reqLogger := log.WithValues("func", "getPodDisruptionBudgetStatus", "namespacedName", namespacedName)
 
-	routeStatusRetrieval := statusRetrieval{
-		names: []string{
-			"cp-console",
-		},
-		f: getAllRouteStatus,
-	}
-
-	// Only check Route status if not CNCF, zen front door is disabled, AND we have Route permissions
-	if !isCncf && !ZenFrontDoorEnabled(ctx, k8sClient, instance.Namespace) {
-		// Check if operator has Route permissions before trying to get Route status
-		routeVerbs := []string{"get"}
-		hasRouteAccess, err := HasAPIAccess(ctx, k8sClient, instance.Namespace, "route.openshift.io", "routes", routeVerbs)
-		if err != nil {
-			reqLogger.Error(err, "Failed to check Route permissions for status retrieval; skipping Route status")
-		} else if !hasRouteAccess {
-			reqLogger.V(1).Info("Operator does not have Route get permission; skipping Route status")
-		} else {
-			statusRetrievals = append(statusRetrievals, routeStatusRetrieval)
-		}
-	}
-
-	status = v1alpha1.ServiceStatus{
-		ObjectName:       instance.Name,
-		Namespace:        instance.Namespace,
-		APIVersion:       instance.APIVersion,
-		Kind:             "CommonWebUI",
-		ManagedResources: []v1alpha1.ManagedResourceStatus{},
-		Status:           NotReady,
-	}
-
-	reqLogger.Info("Getting statuses")
-	for _, getStatuses := range statusRetrievals {
-		status.ManagedResources = append(status.ManagedResources, getStatuses.f(ctx, k8sClient, getStatuses.names, status.Namespace)...)
+	status = v1beta1.ManagedResourceStatus{
+		ObjectName: namespacedName.Name,
+		APIVersion: Unknown,
+		Namespace:  namespacedName.Namespace,
+		Kind:       "PodDisruptionBudget",
+		Status:     NotReady,
 	}
+	pdb := &policyv1.PodDisruptionBudget{}
+	err := k8sClient.Get(ctx, namespacedName, pdb)
 
-	for _, managedResourceStatus := range status.ManagedResources {
-		if managedResourceStatus.Status == NotReady {
-			return
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			reqLogger.Error(err, "Error reading pod disruption budget for status update")
 		}
+		return
 	}
+	status.APIVersion = pdb.APIVersion
 	status.Status = Ready
 	return
 }
@@ -0,0 +1,70 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetIngressStatus(t *testing.T) {
+	key := types.NamespacedName{Name: CnIngressName, Namespace: "cs"}
+
+	tests := []struct {
+		name    string
+		ingress *netv1.Ingress
+		want    string
+	}{
+		{name: "missing", want: NotReady},
+		{
+			name:    "without an address",
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}},
+			want:    Ready,
+		},
+		{
+			name: "with an address",
+			ingress: &netv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Status: netv1.IngressStatus{LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}},
+				}},
+			},
+			want: Ready,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme)
+			if test.ingress != nil {
+				builder = builder.WithObjects(test.ingress)
+			}
+
+			status := getIngressStatus(context.Background(), builder.Build(), key)
+			if status.Status != test.want {
+				t.Errorf("expected %s, got %s", test.want, status.Status)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package resources
 
 import (
 	"context"
+	errorf "errors"
 	"fmt"
 
 	route "github.com/openshift/api/route/v1"
@@ -29,13 +30,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 	im "github.com/IBM/ibm-commonui-operator/apis/operator/v1alpha1"
 )
 
 const CnRouteName = "cp-console"
 const CnRoutePath = "/"
 
+// ErrClusterAddressMissing is returned when the route host cannot be read from the ibmcloud-cluster-info configmap
+var ErrClusterAddressMissing = errorf.New("cluster_address is not available")
+
 var CnAnnotations = map[string]string{
 	"haproxy.router.openshift.io/timeout":                               "90s",
 	"haproxy.router.openshift.io/pod-concurrent-connections":            "100",
@@ -45,206 +49,211 @@ var CnAnnotations = map[string]string{
 	"haproxy.router.openshift.io/rate-limit-connections.rate-tcp":       "100",
 }
 
-func ReconcileRoutes(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
+// routeResource is the cp-console route on OpenShift. It is not created when zen front door support is
+// enabled in the IM authentication CR.
+type routeResource struct{}
+
+func (routeResource) Name() string {
+	return "Route"
+}
+
+func (routeResource) FailedReason(err error) string {
+	if errorf.Is(err, ErrClusterAddressMissing) {
+		return operatorsv1beta1.ReasonClusterInfoMissing
+	}
+	return operatorsv1beta1.ReasonRouteFailed
+}
+
+func (routeResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	//Routes are only served on OpenShift
+	if rc.IsCncf || GetExposureMode(rc) != operatorsv1beta1.ExposureModeRoute {
+		return false
+	}
+	if ZenFrontDoorEnabled(ctx, rc.Client, rc.Instance.Namespace) {
+		log.Info("Zen front door support is enabled - route is not managed", "routeName", CnRouteName)
+		return false
+	}
+	return true
+}
+
+func (routeResource) Object(rc *ReconcileContext) client.Object {
+	return &route.Route{ObjectMeta: metav1.ObjectMeta{Name: CnRouteName, Namespace: rc.Instance.Namespace}}
+}
 
-	reqLogger := log.WithValues("func", "ReconcileRoutes", "namespace", instance.Namespace)
+func (routeResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	instance := rc.Instance
+	reqLogger := log.WithValues("func", "routeResource.Desired", "namespace", instance.Namespace)
 
 	// Check if operator has required Route permissions in the instance namespace
 	routeVerbs := []string{"get", "list", "watch", "create", "delete", "update", "patch"}
-	hasRouteAccess, err := HasAPIAccess(ctx, client, instance.Namespace, "route.openshift.io", "routes", routeVerbs)
+	hasRouteAccess, err := HasAPIAccess(ctx, rc.Client, instance.Namespace, "route.openshift.io", "routes", routeVerbs)
 	if err != nil {
 		reqLogger.Error(err, "Failed to check Route permissions; skipping Route reconciliation")
-		return nil
+		return nil, nil
 	}
 	if !hasRouteAccess {
 		reqLogger.Info("Operator does not have required Route permissions; skipping Route reconciliation")
-		return nil
+		return nil, nil
 	}
 
 	// Also check routes/custom-host subresource permission
-	hasCustomHostAccess, err := HasAPIAccess(ctx, client, instance.Namespace, "route.openshift.io", "routes/custom-host", []string{"create"})
+	hasCustomHostAccess, err := HasAPIAccess(ctx, rc.Client, instance.Namespace, "route.openshift.io", "routes/custom-host", []string{"create"})
 	if err != nil {
 		reqLogger.Error(err, "Failed to check routes/custom-host permissions; skipping Route reconciliation")
-		return nil
+		return nil, nil
 	}
 	if !hasCustomHostAccess {
 		reqLogger.Info("Operator does not have routes/custom-host create permission; skipping Route reconciliation")
-		return nil
+		return nil, nil
 	}
 
-	//If zenFrontDoor is enabled in the IM authentication CR, then we will skip route creation and
-	//delete the route if it already exists
-	if ZenFrontDoorEnabled(ctx, client, instance.Namespace) {
-		reqLogger.Info("Zen front door support is enabled - delete route if it exists", "routeName", CnRouteName)
+	config := getRouteConfig(instance)
+	termination := GetStringWithDefault(config.Termination, operatorsv1beta1.RouteTerminationReencrypt)
+	if termination != operatorsv1beta1.RouteTerminationReencrypt && termination != operatorsv1beta1.RouteTerminationPassthrough {
+		//The pods only serve HTTPS, so a route that connects to them without TLS would not work
+		return nil, fmt.Errorf("route termination %s is not supported", termination)
+	}
 
-		route := &route.Route{}
-		err := client.Get(ctx, types.NamespacedName{Name: CnRouteName, Namespace: instance.Namespace}, route)
+	//Get the destination cert for the route, the router only verifies the service certificate with reencrypt
+	var destinationCAcert []byte
+	if termination == operatorsv1beta1.RouteTerminationReencrypt {
+		certSecretName := GetCertificateSecretName(instance)
+		secret := &corev1.Secret{}
+		err = rc.Reader().Get(ctx, types.NamespacedName{Name: certSecretName, Namespace: instance.Namespace}, secret)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				reqLogger.Info("Route not found - deletion is skipped for zen front door support")
-			} else {
-				reqLogger.Error(err, "Unable to read the route for deletion with zen front door support enabled - route deletion skipped, but reconciliation will proceed")
+				reqLogger.Info("Unable to get route destination certificate, secret does exist. Skipping the route until it is created", "SecretName", certSecretName)
+				return nil, nil
 			}
-			return nil //Do not stop reconciliation if there was an error
+			reqLogger.Error(err, "Failed to get route destination certificate "+certSecretName)
+			return nil, err
 		}
-		err = client.Delete(ctx, route)
+		destinationCAcert = secret.Data["ca.crt"]
+	}
+
+	//The routehost is the cluster_address of the ibmcloud-cluster-info configmap unless it is set in the CR
+	routeHost := config.Host
+	if routeHost == "" {
+		routeHost, err = getClusterAddress(ctx, rc.Client, instance.Namespace)
 		if err != nil {
-			reqLogger.Error(err, "Error deleting route for zen front door support - reconciliation will proceed")
-		} else {
-			reqLogger.Info("Route deleted for zen front door support")
+			return nil, err
 		}
-		return nil //Do not stop reconciliation if there was an error
 	}
 
-	//Get the destination cert for the route
-	secret := &corev1.Secret{}
-	err = client.Get(ctx, types.NamespacedName{Name: UICertSecretName, Namespace: instance.Namespace}, secret)
+	annotations := MergeMap(MergeMap(nil, CnAnnotations), config.Annotations)
+	desiredRoute, err := GetDesiredRoute(rc.Client, instance, CnRouteName, instance.Namespace, annotations, routeHost, CnRoutePath, destinationCAcert)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredRoute.Spec.TLS.Termination = route.TLSTerminationType(termination)
+	if termination == operatorsv1beta1.RouteTerminationPassthrough {
+		//The router does not see the requests of passthrough routes, so it can neither match a path nor
+		//redirect HTTP to HTTPS
+		desiredRoute.Spec.Path = ""
+		desiredRoute.Spec.TLS.InsecureEdgeTerminationPolicy = route.InsecureEdgeTerminationPolicyNone
+	} else if config.TLSSecretName != "" {
+		err = setRouteServingCertificate(ctx, rc.Reader(), desiredRoute, config.TLSSecretName)
+		if err != nil {
+			return nil, err
+		}
+		rc.RequeueWithin(ProvidedSecretResyncInterval)
+	}
+	if config.WildcardPolicy != "" {
+		desiredRoute.Spec.WildcardPolicy = route.WildcardPolicyType(config.WildcardPolicy)
+	}
 
+	return desiredRoute, nil
+}
+
+// getRouteConfig returns the route customization of the CR, the defaults are used when it is not set
+func getRouteConfig(instance *operatorsv1beta1.CommonWebUI) operatorsv1beta1.RouteConfig {
+	if instance.Spec.Route == nil {
+		return operatorsv1beta1.RouteConfig{}
+	}
+	return *instance.Spec.Route
+}
+
+// setRouteServingCertificate sets the certificate, key and CA chain of the route from the secret in the
+// namespace of the route
+func setRouteServingCertificate(ctx context.Context, k8sClient client.Reader, desiredRoute *route.Route, secretName string) error {
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: desiredRoute.Namespace}, secret)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			reqLogger.Info("Unable to get route destination certificate, secret does exist. Requeue and try again", "SecretName", UICertSecretName)
-			*needToRequeue = true
-			return nil
+			return fmt.Errorf("route TLS secret %s was not found", secretName)
 		}
-		reqLogger.Error(err, "Failed to get route destination certificate "+UICertSecretName)
 		return err
 	}
-	destinationCAcert := secret.Data["ca.crt"]
+	if len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return fmt.Errorf("route TLS secret %s must contain %s and %s", secretName, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+
+	desiredRoute.Spec.TLS.Certificate = string(secret.Data[corev1.TLSCertKey])
+	desiredRoute.Spec.TLS.Key = string(secret.Data[corev1.TLSPrivateKeyKey])
+	desiredRoute.Spec.TLS.CACertificate = string(secret.Data["ca.crt"])
+	return nil
+}
+
+// getClusterAddress returns the cluster_address of the ibmcloud-cluster-info configmap, it is the host of the console
+func getClusterAddress(ctx context.Context, k8sClient client.Client, namespace string) (string, error) {
+	reqLogger := log.WithValues("func", "getClusterAddress", "namespace", namespace)
 
-	//Get the routehost from the ibmcloud-cluster-info configmap
-	routeHost := ""
 	clusterInfoConfigMap := &corev1.ConfigMap{}
-	err = client.Get(ctx, types.NamespacedName{Name: ClusterInfoConfigmapName, Namespace: instance.Namespace}, clusterInfoConfigMap)
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: ClusterInfoConfigmapName, Namespace: namespace}, clusterInfoConfigMap)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			//The ibmcloud-cluster-info configmap doesn't exist, reque and try again
+			//The ibmcloud-cluster-info configmap doesn't exist, the request is retried and the configmap watch
+			//will trigger a new reconcile once it is created
 			reqLogger.Info("Cluster info configmap was not found.  Requeue and try again", "configmapName", ClusterInfoConfigmapName)
-			*needToRequeue = true
-			return nil
+			return "", fmt.Errorf("%w: configmap %s was not found", ErrClusterAddressMissing, ClusterInfoConfigmapName)
 		}
 
 		reqLogger.Error(err, "Failed to get cluster info configmap "+ClusterInfoConfigmapName)
-		return err
+		return "", err
 	}
 
 	if clusterInfoConfigMap.Data == nil || len(clusterInfoConfigMap.Data["cluster_address"]) == 0 {
-		return fmt.Errorf("cluster_address is not set in configmap %s", ClusterInfoConfigmapName)
+		return "", fmt.Errorf("%w: cluster_address is not set in configmap %s", ErrClusterAddressMissing, ClusterInfoConfigmapName)
 	}
 
-	routeHost = clusterInfoConfigMap.Data["cluster_address"]
+	return clusterInfoConfigMap.Data["cluster_address"], nil
+}
 
-	err = ReconcileRoute(ctx, client, instance, CnRouteName, CnAnnotations, routeHost, CnRoutePath, destinationCAcert, needToRequeue)
-	if err != nil {
-		return err
+// Mutate recreates the route when its host, wildcard policy or service changes. Annotations and labels added
+// by the customer are not part of the desired route, so the apply leaves them alone. The same goes for a TLS
+// key, certificate and caCertificate placed into the route when spec.route.tlsSecretName is not set.
+func (routeResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	if existing == nil {
+		return false
 	}
-
-	return nil
+	currentRoute := existing.(*route.Route)
+	desiredRoute := desired.(*route.Route)
+
+	//routeHost is immutable so it must be checked first and the route recreated if it has changed
+	//We have discovered that the to:service is also immutable, so we will check that as well
+	//The wildcardPolicy cannot be updated either
+	return currentRoute.Spec.Host != desiredRoute.Spec.Host || currentRoute.Spec.To.Name != desiredRoute.Spec.To.Name ||
+		currentRoute.Spec.WildcardPolicy != desiredRoute.Spec.WildcardPolicy
 }
 
-func ReconcileRoute(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI,
-	name string, annotations map[string]string, routeHost string, routePath string, destinationCAcert []byte, needToRequeue *bool) error {
-
-	namespace := instance.Namespace
-	reqLogger := log.WithValues("func", "ReconcileRoute", "name", name, "namespace", namespace)
-
-	reqLogger.Info("Reconciling route", "annotations", annotations, "routeHost", routeHost, "routePath", routePath)
-
-	desiredRoute, err := GetDesiredRoute(client, instance, name, namespace, annotations, routeHost, routePath, destinationCAcert)
+func (routeResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	// Check if operator has Route permissions before trying to get Route status
+	hasRouteAccess, err := HasAPIAccess(ctx, rc.Client, rc.Instance.Namespace, "route.openshift.io", "routes", []string{"get"})
 	if err != nil {
-		reqLogger.Error(err, "Error creating desired route for reconcilition")
-		return err
+		log.Error(err, "Failed to check Route permissions for status retrieval; skipping Route status")
+		return nil
 	}
-
-	route := &route.Route{}
-	err = client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, route)
-	if err != nil && !errors.IsNotFound(err) {
-		reqLogger.Error(err, "Failed to get existing route for reconciliation")
-		return err
+	if !hasRouteAccess {
+		log.V(1).Info("Operator does not have Route get permission; skipping Route status")
+		return nil
 	}
-
-	if err != nil && errors.IsNotFound(err) {
-		reqLogger.Info("Route not found - creating")
-
-		err = client.Create(ctx, desiredRoute)
-		if err != nil {
-			if errors.IsAlreadyExists(err) {
-				// Route already exists from a previous reconcile
-				reqLogger.Info("Route already exists")
-				*needToRequeue = true
-			} else {
-				// Failed to create a new route
-				reqLogger.Error(err, "Failed to create new route")
-				return err
-			}
-		} else {
-			// Requeue after creating new route
-			*needToRequeue = true
-		}
-	} else {
-		// Determine if current route has changed
-		reqLogger.Info("Comparing current and desired routes")
-
-		//Update the desired route with any existing annotations specified on the existing route.  This will
-		//ensure that any settings added by the customer will not be removed and any updates to existing
-		//annotations are maintained ... this assumes no one will mess with the rewrite path which feels safe
-		for name, annotation := range route.Annotations {
-			desiredRoute.Annotations[name] = annotation
-		}
-
-		//Overlay any additional labels the customer may have added to the route, while preserving those we own
-		for name, label := range route.Labels {
-			_, exists := desiredRoute.Labels[name]
-			if !exists {
-				desiredRoute.Labels[name] = label
-			}
-		}
-
-		// Update the desired route if it contains a TLS certificate, caCertificate and key
-		// This will preserve the certificate that has been placed into the route
-		if route.Spec.TLS != nil && route.Spec.TLS.Key != "" && route.Spec.TLS.Certificate != "" && route.Spec.TLS.CACertificate != "" {
-			reqLogger.Info("Updating route with TLS key, certificate and caCertificate")
-			desiredRoute.Spec.TLS.Key = route.Spec.TLS.Key
-			desiredRoute.Spec.TLS.Certificate = route.Spec.TLS.Certificate
-			desiredRoute.Spec.TLS.CACertificate = route.Spec.TLS.CACertificate
-		}
-
-		//routeHost is immutable so it must be checked first and the route recreated if it has changed
-		//We have discovered that the to:service is also immutable, so we will check that as well
-		if route.Spec.Host != desiredRoute.Spec.Host || route.Spec.To.Name != desiredRoute.Spec.To.Name {
-			err = client.Delete(ctx, route)
-			if err != nil {
-				reqLogger.Error(err, "Route host or service name changed, unable to delete existing route for recreate")
-				return err
-			}
-			//Recreate the route
-			err = client.Create(ctx, desiredRoute)
-			if err != nil {
-				reqLogger.Error(err, "Route host or service name changed, unable to create new route")
-				return err
-			}
-			*needToRequeue = true
-			return nil
-		}
-
-		if !IsRouteEqual(route, desiredRoute) {
-			reqLogger.Info("Updating route")
-
-			route.ObjectMeta.Name = desiredRoute.ObjectMeta.Name
-			route.ObjectMeta.Annotations = desiredRoute.ObjectMeta.Annotations
-			route.ObjectMeta.Labels = desiredRoute.ObjectMeta.Labels
-			route.Spec = desiredRoute.Spec
-
-			err = client.Update(ctx, route)
-			if err != nil {
-				reqLogger.Error(err, "Failed to update route")
-				return err
-			}
-		}
+	return []operatorsv1beta1.ManagedResourceStatus{
+		getRouteStatus(ctx, rc.Client, types.NamespacedName{Name: CnRouteName, Namespace: rc.Instance.Namespace}),
 	}
-	return nil
 }
 
-func GetDesiredRoute(client client.Client, instance *operatorsv1alpha1.CommonWebUI, name string, namespace string,
+func GetDesiredRoute(client client.Client, instance *operatorsv1beta1.CommonWebUI, name string, namespace string,
 	annotations map[string]string, routeHost string, routePath string, destinationCAcert []byte) (*route.Route, error) {
 
 	reqLogger := log.WithValues("func", "GetDesiredRoute", "name", name, "namespace", namespace)
@@ -0,0 +1,419 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"time"
+
+	certmgr "github.com/ibm/ibm-cert-manager-operator/apis/cert-manager/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+// IsSelfSignedCertificate returns true when the operator issues the common-web-ui certificate itself, because
+// the certificate secret is not provided in the CR and cert-manager is not installed
+func IsSelfSignedCertificate(rc *ReconcileContext) bool {
+	if IsCertificateProvided(rc.Instance) {
+		return false
+	}
+	_, err := rc.Client.RESTMapper().RESTMapping(certmgr.GroupVersion.WithKind("Certificate").GroupKind(), certmgr.GroupVersion.Version)
+	return meta.IsNoMatchError(err)
+}
+
+// selfSignedCAResource is the CA that issues the self-signed common-web-ui certificate. The CA is renewed a
+// year before it expires, ca.crt keeps the previous CA until it expires so the certificates it issued are
+// still trusted while the pods restart with the new certificate.
+type selfSignedCAResource struct{}
+
+func (selfSignedCAResource) Name() string {
+	return "SelfSignedCA"
+}
+
+func (selfSignedCAResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonCertificateFailed
+}
+
+func (selfSignedCAResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return IsSelfSignedCertificate(rc)
+}
+
+func (selfSignedCAResource) Object(rc *ReconcileContext) client.Object {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: SelfSignedCASecretName, Namespace: rc.Instance.Namespace}}
+}
+
+func (selfSignedCAResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	instance := rc.Instance
+	reqLogger := log.WithValues("func", "selfSignedCAResource.Desired", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	existing, err := getSecretData(ctx, rc.Reader(), SelfSignedCASecretName, instance.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	caCert, _, err := parseKeyPair(existing[corev1.TLSCertKey], existing[corev1.TLSPrivateKeyKey])
+	if err != nil || !isCAValid(caCert, now) {
+		reqLogger.Info("Issuing the self-signed CA of the common-web-ui certificate", "SecretName", SelfSignedCASecretName)
+		caCert, existing[corev1.TLSCertKey], existing[corev1.TLSPrivateKeyKey], err = issueCertificate(
+			&x509.Certificate{
+				Subject:               pkix.Name{CommonName: UICertCommonName + "-ca"},
+				IsCA:                  true,
+				BasicConstraintsValid: true,
+				KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+			}, SelfSignedCADuration, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rc.RequeueWithin(getRenewalTime(caCert, SelfSignedCARenewBefore).Sub(now))
+
+	//The current CA comes first, followed by the previous CAs that have not expired yet
+	caBundle := []*x509.Certificate{caCert}
+	for _, cert := range parseCertificates(existing["ca.crt"]) {
+		if cert.Equal(caCert) || !now.Before(cert.NotAfter) {
+			continue
+		}
+		caBundle = append(caBundle, cert)
+		rc.RequeueWithin(cert.NotAfter.Sub(now))
+	}
+
+	return newCertificateSecret(rc, SelfSignedCASecretName, existing[corev1.TLSCertKey], existing[corev1.TLSPrivateKeyKey], encodeCertificates(caBundle))
+}
+
+func (selfSignedCAResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
+
+func (selfSignedCAResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return nil
+}
+
+func (selfSignedCAResource) Generated() bool {
+	return true
+}
+
+// selfSignedCertificateResource is the common-web-ui-cert secret issued by the self-signed CA, with the same
+// layout as the secret issued by cert-manager. The certificate uses the lifetime and SANs of the CR and is
+// issued again when they change or the CA is renewed. The secret is left in place when cert-manager is
+// installed later on, cert-manager takes it over.
+type selfSignedCertificateResource struct{}
+
+func (selfSignedCertificateResource) Name() string {
+	return "SelfSignedCertificate"
+}
+
+func (selfSignedCertificateResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonCertificateFailed
+}
+
+func (selfSignedCertificateResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	//A disabled component would delete the secret, which may have been provided in the CR
+	return true
+}
+
+func (selfSignedCertificateResource) Object(rc *ReconcileContext) client.Object {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: UICertSecretName, Namespace: rc.Instance.Namespace}}
+}
+
+func (selfSignedCertificateResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	instance := rc.Instance
+	reqLogger := log.WithValues("func", "selfSignedCertificateResource.Desired", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	if !IsSelfSignedCertificate(rc) {
+		return nil, nil
+	}
+
+	ca, err := getSecretData(ctx, rc.Reader(), SelfSignedCASecretName, instance.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	caCert, caKey, err := parseKeyPair(ca[corev1.TLSCertKey], ca[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		reqLogger.Info("Self-signed CA is not available yet - skipping the certificate", "SecretName", SelfSignedCASecretName)
+		return nil, nil
+	}
+
+	existing, err := getSecretData(ctx, rc.Reader(), UICertSecretName, instance.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	config := getCertificateConfig(instance)
+	dnsNames := getCertificateDNSNames(UICertificateData.Common, instance.Namespace, config)
+	ipAddresses := []net.IP{}
+	for _, address := range config.IPAddresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return nil, fmt.Errorf("spec.certificate.ipAddresses contains the invalid IP address %q", address)
+		}
+		ipAddresses = append(ipAddresses, ip)
+	}
+	renewBefore := getCertificateRenewBefore(config)
+
+	now := time.Now()
+	cert, _, err := parseKeyPair(existing[corev1.TLSCertKey], existing[corev1.TLSPrivateKeyKey])
+	if err != nil || !isCertificateValid(cert, caCert, renewBefore, dnsNames, ipAddresses, now) {
+		reqLogger.Info("Issuing the self-signed common-web-ui certificate", "SecretName", UICertSecretName)
+		cert, existing[corev1.TLSCertKey], existing[corev1.TLSPrivateKeyKey], err = issueCertificate(
+			&x509.Certificate{
+				Subject:     pkix.Name{CommonName: UICertificateData.Common},
+				DNSNames:    dnsNames,
+				IPAddresses: ipAddresses,
+				KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			}, getCertificateDuration(config), caCert, caKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rc.RequeueWithin(getRenewalTime(cert, renewBefore).Sub(now))
+
+	//The CA bundle includes the previous CA while it is valid, the route trusts the pods that still use a
+	//certificate of the previous CA during the restart
+	return newCertificateSecret(rc, UICertSecretName, existing[corev1.TLSCertKey], existing[corev1.TLSPrivateKeyKey], ca["ca.crt"])
+}
+
+func (selfSignedCertificateResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
+
+func (selfSignedCertificateResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return nil
+}
+
+func (selfSignedCertificateResource) Generated() bool {
+	return true
+}
+
+// getSelfSignedCertificateSerial returns the serial number of the self-signed common-web-ui certificate, or
+// an empty string when it has not been issued
+func getSelfSignedCertificateSerial(ctx context.Context, rc *ReconcileContext) (string, error) {
+	data, err := getSecretData(ctx, rc.Reader(), UICertSecretName, rc.Instance.Namespace)
+	if err != nil {
+		return "", err
+	}
+	certs := parseCertificates(data[corev1.TLSCertKey])
+	if len(certs) == 0 {
+		return "", nil
+	}
+	return certs[0].SerialNumber.Text(16), nil
+}
+
+func newCertificateSecret(rc *ReconcileContext, name string, cert []byte, key []byte, caCert []byte) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: rc.Instance.Namespace,
+			Labels:    MergeMap(LabelsForMetadata(name), rc.Instance.Spec.Labels),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+			"ca.crt":                caCert,
+		},
+	}
+	return secret, setControllerReference(rc, secret)
+}
+
+// getSecretData returns the data of the secret, or empty data when the secret does not exist
+func getSecretData(ctx context.Context, k8sClient client.Reader, name string, namespace string) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return map[string][]byte{}, nil
+		}
+		return nil, err
+	}
+	if secret.Data == nil {
+		return map[string][]byte{}, nil
+	}
+	return secret.Data, nil
+}
+
+// issueCertificate returns a certificate for the template with a new ECDSA P-256 key, signed by the parent or
+// self-signed when there is no parent. The certificate does not outlive its parent.
+func issueCertificate(template *x509.Certificate, duration time.Duration, parent *x509.Certificate,
+	parentKey crypto.Signer) (*x509.Certificate, []byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	now := time.Now()
+	template.SerialNumber = serialNumber
+	template.NotBefore = now.Add(-5 * time.Minute)
+	template.NotAfter = now.Add(duration)
+	if parent == nil {
+		parent = template
+		parentKey = key
+	} else if template.NotAfter.After(parent.NotAfter) {
+		template.NotAfter = parent.NotAfter
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cert, encodeCertificates([]*x509.Certificate{cert}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}), nil
+}
+
+// parseKeyPair returns the first certificate of the PEM data and its private key
+func parseKeyPair(certPEM []byte, keyPEM []byte) (*x509.Certificate, crypto.Signer, error) {
+	certs := parseCertificates(certPEM)
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("no certificate found")
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, nil, fmt.Errorf("no private key found")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	//The public key of the certificate must belong to the private key
+	publicKey, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(certs[0].PublicKey) {
+		return nil, nil, fmt.Errorf("private key does not match the certificate")
+	}
+	return certs[0], signer, nil
+}
+
+func parseCertificates(data []byte) []*x509.Certificate {
+	certs := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+func encodeCertificates(certs []*x509.Certificate) []byte {
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return buf.Bytes()
+}
+
+// getRenewalTime returns when the certificate is renewed, a third of its lifetime before it expires when the
+// renewBefore is not shorter than the lifetime
+func getRenewalTime(cert *x509.Certificate, renewBefore time.Duration) time.Time {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	if renewBefore >= lifetime {
+		renewBefore = lifetime / 3
+	}
+	return cert.NotAfter.Add(-renewBefore)
+}
+
+// isCAValid returns true when the CA can keep issuing the certificate until it is due for renewal
+func isCAValid(caCert *x509.Certificate, now time.Time) bool {
+	return caCert.IsCA && now.Before(getRenewalTime(caCert, SelfSignedCARenewBefore))
+}
+
+// isCertificateValid returns true when the certificate is issued by the CA for the SANs and is not due for
+// renewal, otherwise it is issued again
+func isCertificateValid(cert *x509.Certificate, caCert *x509.Certificate, renewBefore time.Duration, dnsNames []string,
+	ipAddresses []net.IP, now time.Time) bool {
+	return cert.CheckSignatureFrom(caCert) == nil && now.Before(getRenewalTime(cert, renewBefore)) &&
+		equalSANs(cert, dnsNames, ipAddresses)
+}
+
+func equalSANs(cert *x509.Certificate, dnsNames []string, ipAddresses []net.IP) bool {
+	if !equalStrings(cert.DNSNames, dnsNames) || len(cert.IPAddresses) != len(ipAddresses) {
+		return false
+	}
+	certAddresses := []string{}
+	addresses := []string{}
+	for i := range ipAddresses {
+		certAddresses = append(certAddresses, cert.IPAddresses[i].String())
+		addresses = append(addresses, ipAddresses[i].String())
+	}
+	return equalStrings(certAddresses, addresses)
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+func TestGetRenewalTime(t *testing.T) {
+	notBefore := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert := &x509.Certificate{NotBefore: notBefore, NotAfter: notBefore.Add(90 * 24 * time.Hour)}
+
+	tests := []struct {
+		name        string
+		renewBefore time.Duration
+		want        time.Time
+	}{
+		{name: "renew before within the lifetime", renewBefore: 30 * 24 * time.Hour, want: notBefore.Add(60 * 24 * time.Hour)},
+		{name: "renew before longer than the lifetime", renewBefore: 120 * 24 * time.Hour, want: notBefore.Add(60 * 24 * time.Hour)},
+		{name: "renew before equal to the lifetime", renewBefore: 90 * 24 * time.Hour, want: notBefore.Add(60 * 24 * time.Hour)},
+		{name: "no renew before", want: cert.NotAfter},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getRenewalTime(cert, test.renewBefore); !got.Equal(test.want) {
+				t.Errorf("expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestIsCertificateValid(t *testing.T) {
+	caCert, caKey := issueTestCA(t)
+	otherCA, _ := issueTestCA(t)
+
+	dnsNames := []string{"common-web-ui", "common-web-ui.cs"}
+	ipAddresses := []net.IP{net.ParseIP("10.0.0.1")}
+	cert, _, _, err := issueCertificate(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: "common-web-ui"},
+		DNSNames:    dnsNames,
+		IPAddresses: ipAddresses,
+	}, 90*24*time.Hour, caCert, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renewBefore := 30 * 24 * time.Hour
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		caCert      *x509.Certificate
+		dnsNames    []string
+		ipAddresses []net.IP
+		now         time.Time
+		want        bool
+	}{
+		{name: "valid", caCert: caCert, dnsNames: dnsNames, ipAddresses: ipAddresses, now: now, want: true},
+		{name: "DNS names in another order", caCert: caCert, dnsNames: []string{"common-web-ui.cs", "common-web-ui"},
+			ipAddresses: ipAddresses, now: now, want: true},
+		{name: "within the renewal window", caCert: caCert, dnsNames: dnsNames, ipAddresses: ipAddresses, now: now.Add(61 * 24 * time.Hour)},
+		{name: "expired", caCert: caCert, dnsNames: dnsNames, ipAddresses: ipAddresses, now: now.Add(91 * 24 * time.Hour)},
+		{name: "DNS name added", caCert: caCert, dnsNames: append([]string{"console.example.com"}, dnsNames...),
+			ipAddresses: ipAddresses, now: now},
+		{name: "IP address changed", caCert: caCert, dnsNames: dnsNames, ipAddresses: []net.IP{net.ParseIP("10.0.0.2")}, now: now},
+		{name: "IP address removed", caCert: caCert, dnsNames: dnsNames, now: now},
+		{name: "issued by another CA", caCert: otherCA, dnsNames: dnsNames, ipAddresses: ipAddresses, now: now},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isCertificateValid(cert, test.caCert, renewBefore, test.dnsNames, test.ipAddresses, test.now); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestIsCAValid(t *testing.T) {
+	caCert, _ := issueTestCA(t)
+	now := time.Now()
+
+	if !isCAValid(caCert, now) {
+		t.Errorf("expected a new CA to be valid")
+	}
+	if isCAValid(caCert, now.Add(SelfSignedCADuration-SelfSignedCARenewBefore+time.Hour)) {
+		t.Errorf("expected the CA to be renewed within %s of its expiry", SelfSignedCARenewBefore)
+	}
+	leaf := *caCert
+	leaf.IsCA = false
+	if isCAValid(&leaf, now) {
+		t.Errorf("expected a certificate that is not a CA to be issued again")
+	}
+}
+
+func TestSelfSignedCertificateReuse(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorsv1beta1.AddToScheme(scheme)
+
+	instance := &operatorsv1beta1.CommonWebUI{}
+	instance.Name = "example-commonwebui"
+	instance.Namespace = "cs"
+	//cert-manager is not installed, so the operator issues the certificate itself
+	rc := &ReconcileContext{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(meta.NewDefaultRESTMapper(nil)).Build(),
+		Instance: instance,
+	}
+	ctx := context.Background()
+
+	reconcile := func(resource ManagedResource) *corev1.Secret {
+		t.Helper()
+		desired, err := resource.Desired(ctx, rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if desired == nil {
+			t.Fatalf("expected a desired %s secret", resource.Name())
+		}
+		existing := resource.Object(rc)
+		if err := rc.Client.Get(ctx, client.ObjectKeyFromObject(existing), existing); err == nil {
+			desired.SetResourceVersion(existing.GetResourceVersion())
+			err = rc.Client.Update(ctx, desired)
+			if err != nil {
+				t.Fatal(err)
+			}
+		} else if err := rc.Client.Create(ctx, desired); err != nil {
+			t.Fatal(err)
+		}
+		return desired.(*corev1.Secret)
+	}
+
+	ca := reconcile(selfSignedCAResource{})
+	cert := reconcile(selfSignedCertificateResource{})
+
+	if again := reconcile(selfSignedCAResource{}); !bytes.Equal(again.Data[corev1.TLSCertKey], ca.Data[corev1.TLSCertKey]) {
+		t.Errorf("expected the valid CA to be kept")
+	}
+	if again := reconcile(selfSignedCertificateResource{}); !bytes.Equal(again.Data[corev1.TLSCertKey], cert.Data[corev1.TLSCertKey]) {
+		t.Errorf("expected the valid certificate to be kept")
+	}
+
+	instance.Spec.Certificate = &operatorsv1beta1.CertificateConfig{DNSNames: []string{"console.example.com"}}
+	reissued := reconcile(selfSignedCertificateResource{})
+	if bytes.Equal(reissued.Data[corev1.TLSCertKey], cert.Data[corev1.TLSCertKey]) {
+		t.Errorf("expected the certificate to be issued again for the new DNS name")
+	}
+	certs := parseCertificates(reissued.Data[corev1.TLSCertKey])
+	if len(certs) != 1 || !ContainsString(certs[0].DNSNames, "console.example.com") {
+		t.Errorf("expected the DNS name console.example.com in the certificate")
+	}
+	if again := reconcile(selfSignedCAResource{}); !bytes.Equal(again.Data[corev1.TLSCertKey], ca.Data[corev1.TLSCertKey]) {
+		t.Errorf("expected the CA to be kept when the certificate is issued again")
+	}
+}
+
+func issueTestCA(t *testing.T) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+	_, certPEM, keyPEM, err := issueCertificate(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: "common-web-ui-ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}, SelfSignedCADuration, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, caKey, err := parseKeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return caCert, caKey
+}
+
+func TestSelfSignedCertificateSerialAnnotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorsv1beta1.AddToScheme(scheme)
+
+	instance := &operatorsv1beta1.CommonWebUI{}
+	instance.Name = "example-commonwebui"
+	instance.Namespace = "cs"
+	rc := &ReconcileContext{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(meta.NewDefaultRESTMapper(nil)).Build(),
+		Instance: instance,
+		IsCncf:   true,
+	}
+	ctx := context.Background()
+
+	for _, resource := range []ManagedResource{selfSignedCAResource{}, selfSignedCertificateResource{}} {
+		desired, err := resource.Desired(ctx, rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rc.Client.Create(ctx, desired); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	desired, err := deploymentResource{}.Desired(ctx, rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desired.(*appsv1.Deployment).Spec.Template.Annotations[CertificateSerialAnnotation] == "" {
+		t.Errorf("expected the %s annotation on the pod template", CertificateSerialAnnotation)
+	}
+	//The serial of one CR must not end up in the defaults of the others
+	if _, found := DeploymentAnnotations[CertificateSerialAnnotation]; found {
+		t.Errorf("expected DeploymentAnnotations to be left unchanged, got %v", DeploymentAnnotations)
+	}
+}
@@ -20,17 +20,16 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 )
 
-func getDesiredService(client client.Client, instance *operatorsv1alpha1.CommonWebUI) (*corev1.Service, error) {
+func getDesiredService(client client.Client, instance *operatorsv1beta1.CommonWebUI) (*corev1.Service, error) {
 	reqLogger := log.WithValues("func", "getDesiredService", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
 
 	metaLabels := LabelsForMetadata(ServiceName)
@@ -76,59 +75,35 @@ func getDesiredService(client client.Client, instance *operatorsv1alpha1.CommonW
 	return service, nil
 }
 
-func ReconcileService(ctx context.Context, client client.Client, instance *operatorsv1alpha1.CommonWebUI, needToRequeue *bool) error {
-	reqLogger := log.WithValues("func", "reconcileService", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
-	reqLogger.Info("Reconciling service")
+// serviceResource is the service of the common-web-ui pods
+type serviceResource struct{}
 
-	service := &corev1.Service{}
+func (serviceResource) Name() string {
+	return "Service"
+}
 
-	desiredService, desiredErr := getDesiredService(client, instance)
-	if desiredErr != nil {
-		return desiredErr
-	}
+func (serviceResource) FailedReason(err error) string {
+	return operatorsv1beta1.ReasonServiceFailed
+}
 
-	err := client.Get(ctx, types.NamespacedName{Name: ServiceName, Namespace: instance.Namespace}, service)
-
-	if err != nil && errors.IsNotFound(err) {
-		reqLogger.Info("Creating a new service", "Service.Namespace", desiredService.Namespace, "Service.Name", desiredService.Name)
-
-		err = client.Create(ctx, desiredService)
-		if err != nil {
-			if errors.IsAlreadyExists(err) {
-				// Service already exists from a previous reconcile
-				reqLogger.Info("Service already exists")
-				*needToRequeue = true
-			} else {
-				// Failed to create a new service
-				reqLogger.Info("Failed to create a new service", "Service.Namespace", desiredService.Namespace, "Service.Name", desiredService.Name)
-				return err
-			}
-		} else {
-			// Requeue after creating new service
-			*needToRequeue = true
-		}
-	} else if err != nil && !errors.IsNotFound(err) {
-		reqLogger.Error(err, "Failed to get service", "Service.Namespace", instance.Namespace, "Service.Name", ServiceName)
-		return err
-	} else {
-		// Determine if current service has changed
-		reqLogger.Info("Comparing current and desired services")
-
-		if !IsServiceEqual(service, desiredService) {
-			reqLogger.Info("Updating service", "Service.Namespace", service.Namespace, "Service.Name", service.Name)
-
-			service.ObjectMeta.Name = desiredService.ObjectMeta.Name
-			service.ObjectMeta.Labels = desiredService.ObjectMeta.Labels
-			service.Spec.Ports = desiredService.Spec.Ports
-			service.Spec.Selector = desiredService.Spec.Selector
-
-			err = client.Update(ctx, service)
-			if err != nil {
-				reqLogger.Error(err, "Failed to update service", "Service.Namespace", service.Namespace, "Service.Name", service.Name)
-				return err
-			}
-		}
-	}
+func (serviceResource) Enabled(ctx context.Context, rc *ReconcileContext) bool {
+	return true
+}
+
+func (serviceResource) Object(rc *ReconcileContext) client.Object {
+	return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: ServiceName, Namespace: rc.Instance.Namespace}}
+}
+
+func (serviceResource) Desired(ctx context.Context, rc *ReconcileContext) (client.Object, error) {
+	return getDesiredService(rc.Client, rc.Instance)
+}
 
-	return nil
+func (serviceResource) Mutate(rc *ReconcileContext, existing, desired client.Object) bool {
+	return false
+}
+
+func (serviceResource) Status(ctx context.Context, rc *ReconcileContext) []operatorsv1beta1.ManagedResourceStatus {
+	return []operatorsv1beta1.ManagedResourceStatus{
+		getServiceStatus(ctx, rc.Client, types.NamespacedName{Name: ServiceName, Namespace: rc.Instance.Namespace}),
+	}
 }
@@ -0,0 +1,207 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+const SwitcherConfigMapName = "common-web-ui-switcher"
+const SwitcherConfigMapKey = "switcher.json"
+
+// SwitcherModel is the product switcher content published to common-web-ui
+type SwitcherModel struct {
+	Items []SwitcherModelItem `json:"items"`
+}
+
+// SwitcherModelItem is a single entry of the product switcher
+type SwitcherModelItem struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	Label       string `json:"label"`
+	Display     string `json:"display"`
+	LogoURL     string `json:"logoURL,omitempty"`
+	LandingPage string `json:"landingPage,omitempty"`
+}
+
+// ValidateCloudPakInfo checks the switcher item fields that common-web-ui relies on
+func ValidateCloudPakInfo(info operatorsv1alpha1.CloudPakInfo, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if strings.TrimSpace(info.Label) == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("label"), "label identifies the item in the product switcher"))
+	}
+
+	if info.LandingPage != "" {
+		allErrs = append(allErrs, operatorsv1beta1.ValidateLandingPage(info.LandingPage, fldPath.Child("landingPage"))...)
+	}
+
+	if info.LogoURL != "" {
+		logoURL, err := url.Parse(info.LogoURL)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("logoURL"), info.LogoURL, err.Error()))
+		case logoURL.Scheme == "https":
+			if logoURL.Host == "" {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("logoURL"), info.LogoURL, "https URL must include a host"))
+			}
+		case logoURL.Scheme == "data":
+			if !strings.HasPrefix(logoURL.Opaque, "image/") {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("logoURL"), info.LogoURL, "data URL must contain an image"))
+			}
+		case logoURL.Scheme == "" && logoURL.Host == "" && strings.HasPrefix(logoURL.Path, "/"):
+			// Path served by the console
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("logoURL"), info.LogoURL,
+				[]string{"https://<host>/<path>", "data:image/<type>", "/<path>"}))
+		}
+	}
+
+	return allErrs
+}
+
+// BuildSwitcherModel orders the valid switcher items by display name and drops items that reuse
+// the label of an older item. The returned map links each dropped item to the item that was kept.
+func BuildSwitcherModel(items []operatorsv1alpha1.SwitcherItem) (SwitcherModel, map[types.NamespacedName]types.NamespacedName) {
+	sorted := make([]operatorsv1alpha1.SwitcherItem, len(items))
+	copy(sorted, items)
+
+	// Oldest item wins when labels collide
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		return switcherItemKey(&sorted[i]).String() < switcherItemKey(&sorted[j]).String()
+	})
+
+	duplicates := map[types.NamespacedName]types.NamespacedName{}
+	published := map[string]types.NamespacedName{}
+	model := SwitcherModel{Items: []SwitcherModelItem{}}
+	for i := range sorted {
+		item := &sorted[i]
+		label := strings.TrimSpace(item.Spec.CloudPakInfo.Label)
+		if owner, found := published[label]; found {
+			duplicates[switcherItemKey(item)] = owner
+			continue
+		}
+		published[label] = switcherItemKey(item)
+
+		display := item.Spec.CloudPakInfo.Display
+		if display == "" {
+			display = label
+		}
+		model.Items = append(model.Items, SwitcherModelItem{
+			Name:        item.Name,
+			Namespace:   item.Namespace,
+			Label:       label,
+			Display:     display,
+			LogoURL:     item.Spec.CloudPakInfo.LogoURL,
+			LandingPage: item.Spec.CloudPakInfo.LandingPage,
+		})
+	}
+
+	sort.SliceStable(model.Items, func(i, j int) bool {
+		di, dj := strings.ToLower(model.Items[i].Display), strings.ToLower(model.Items[j].Display)
+		if di != dj {
+			return di < dj
+		}
+		return model.Items[i].Label < model.Items[j].Label
+	})
+
+	return model, duplicates
+}
+
+func switcherItemKey(item *operatorsv1alpha1.SwitcherItem) types.NamespacedName {
+	return types.NamespacedName{Name: item.Name, Namespace: item.Namespace}
+}
+
+// SetSwitcherItemCondition sets or updates a condition on the SwitcherItem status
+func SetSwitcherItemCondition(item *operatorsv1alpha1.SwitcherItem, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&item.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: item.Generation,
+	})
+}
+
+func getDesiredSwitcherConfigMap(client client.Client, instance *operatorsv1beta1.CommonWebUI, model SwitcherModel) (*corev1.ConfigMap, error) {
+	reqLogger := log.WithValues("func", "getDesiredSwitcherConfigMap", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+
+	data, err := json.Marshal(model)
+	if err != nil {
+		reqLogger.Error(err, "Failed to marshal switcher model")
+		return nil, err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SwitcherConfigMapName,
+			Namespace: instance.Namespace,
+			Labels:    LabelsForMetadata(SwitcherConfigMapName),
+		},
+		Data: map[string]string{
+			SwitcherConfigMapKey: string(data),
+		},
+	}
+
+	err = controllerutil.SetControllerReference(instance, cm, client.Scheme())
+	if err != nil {
+		reqLogger.Error(err, "Failed to set owner for switcher configmap")
+		return nil, err
+	}
+
+	return cm, nil
+}
+
+// ReconcileSwitcherConfigMap publishes the switcher model in the namespace of the CommonWebUI instance
+func ReconcileSwitcherConfigMap(ctx context.Context, client client.Client, instance *operatorsv1beta1.CommonWebUI, model SwitcherModel) error {
+	reqLogger := log.WithValues("func", "ReconcileSwitcherConfigMap", "instance.Name", instance.Name, "instance.Namespace", instance.Namespace)
+	reqLogger.Info("Reconciling switcher configmap")
+
+	desiredCM, err := getDesiredSwitcherConfigMap(client, instance, model)
+	if err != nil {
+		return err
+	}
+
+	result, err := ApplyResource(ctx, client, desiredCM)
+	if err != nil {
+		reqLogger.Error(err, "Failed to apply switcher configmap")
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		reqLogger.Info("Applied switcher configmap", "result", result, "items", len(model.Items))
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+func TestValidateCloudPakInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		info operatorsv1alpha1.CloudPakInfo
+		// errors lists the field paths that are expected to be rejected, an empty list means the item is valid
+		errors []string
+	}{
+		{name: "label only", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d"}},
+		{name: "missing label", info: operatorsv1alpha1.CloudPakInfo{Display: "Cloud Pak for Data"}, errors: []string{"spec.cloudPakInfo.label"}},
+		{name: "blank label", info: operatorsv1alpha1.CloudPakInfo{Label: "  "}, errors: []string{"spec.cloudPakInfo.label"}},
+		{name: "absolute landing page", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LandingPage: "/zen"}},
+		{name: "relative landing page", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LandingPage: "zen"},
+			errors: []string{"spec.cloudPakInfo.landingPage"}},
+		{name: "https logo", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LogoURL: "https://example.com/logo.svg"}},
+		{name: "https logo without a host", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LogoURL: "https:///logo.svg"},
+			errors: []string{"spec.cloudPakInfo.logoURL"}},
+		{name: "data image logo", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LogoURL: "data:image/svg+xml;base64,PHN2Zz4="}},
+		{name: "data logo that is not an image", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LogoURL: "data:text/html,<script>"},
+			errors: []string{"spec.cloudPakInfo.logoURL"}},
+		{name: "logo path of the console", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LogoURL: "/common-nav/graphics/logo.svg"}},
+		{name: "http logo", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LogoURL: "http://example.com/logo.svg"},
+			errors: []string{"spec.cloudPakInfo.logoURL"}},
+		{name: "javascript logo", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LogoURL: "javascript:alert(1)"},
+			errors: []string{"spec.cloudPakInfo.logoURL"}},
+		{name: "relative logo path", info: operatorsv1alpha1.CloudPakInfo{Label: "cp4d", LogoURL: "logo.svg"},
+			errors: []string{"spec.cloudPakInfo.logoURL"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidateCloudPakInfo(test.info, field.NewPath("spec", "cloudPakInfo"))
+			if len(test.errors) == 0 {
+				if len(errs) > 0 {
+					t.Fatalf("expected the item to be valid, got %v", errs.ToAggregate())
+				}
+				return
+			}
+			if len(errs) != len(test.errors) {
+				t.Fatalf("expected errors for %v, got %v", test.errors, errs.ToAggregate())
+			}
+			for i, path := range test.errors {
+				if !strings.HasPrefix(errs[i].Field, path) {
+					t.Errorf("expected an error for %s, got %v", path, errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildSwitcherModel(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	item := func(name, namespace, label, display string, age time.Duration) operatorsv1alpha1.SwitcherItem {
+		return operatorsv1alpha1.SwitcherItem{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(created.Add(-age))},
+			Spec:       operatorsv1alpha1.SwitcherItemSpec{CloudPakInfo: operatorsv1alpha1.CloudPakInfo{Label: label, Display: display}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		items      []operatorsv1alpha1.SwitcherItem
+		want       []string
+		duplicates map[types.NamespacedName]types.NamespacedName
+	}{
+		{
+			name: "no items",
+			want: []string{},
+		},
+		{
+			name: "sorted by display name ignoring case",
+			items: []operatorsv1alpha1.SwitcherItem{
+				item("zen", "cs", "cp4d", "cloud Pak for Data", time.Hour),
+				item("aiops", "cs", "aiops", "Cloud Pak for AIOps", time.Hour),
+				item("ba", "cs", "cp4ba", "Business Automation", time.Hour),
+			},
+			want: []string{"cp4ba", "aiops", "cp4d"},
+		},
+		{
+			name: "label is displayed when there is no display name",
+			items: []operatorsv1alpha1.SwitcherItem{
+				item("b", "cs", "beta", "", time.Hour),
+				item("a", "cs", "alpha", "", time.Hour),
+			},
+			want: []string{"alpha", "beta"},
+		},
+		{
+			name: "same display name is sorted by label",
+			items: []operatorsv1alpha1.SwitcherItem{
+				item("b", "cs", "b", "Console", time.Hour),
+				item("a", "cs", "a", "Console", time.Hour),
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "oldest item wins a label",
+			items: []operatorsv1alpha1.SwitcherItem{
+				item("newer", "cs", "cp4d", "Newer", time.Hour),
+				item("older", "other", "cp4d", "Older", 2*time.Hour),
+			},
+			want: []string{"cp4d"},
+			duplicates: map[types.NamespacedName]types.NamespacedName{
+				{Name: "newer", Namespace: "cs"}: {Name: "older", Namespace: "other"},
+			},
+		},
+		{
+			name: "labels are compared without surrounding spaces",
+			items: []operatorsv1alpha1.SwitcherItem{
+				item("older", "cs", "cp4d", "", 2*time.Hour),
+				item("newer", "cs", " cp4d ", "", time.Hour),
+			},
+			want: []string{"cp4d"},
+			duplicates: map[types.NamespacedName]types.NamespacedName{
+				{Name: "newer", Namespace: "cs"}: {Name: "older", Namespace: "cs"},
+			},
+		},
+		{
+			name: "same creation time is decided by namespace and name",
+			items: []operatorsv1alpha1.SwitcherItem{
+				item("b", "cs", "cp4d", "", time.Hour),
+				item("a", "cs", "cp4d", "", time.Hour),
+			},
+			want: []string{"cp4d"},
+			duplicates: map[types.NamespacedName]types.NamespacedName{
+				{Name: "b", Namespace: "cs"}: {Name: "a", Namespace: "cs"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model, duplicates := BuildSwitcherModel(test.items)
+
+			labels := []string{}
+			for _, modelItem := range model.Items {
+				labels = append(labels, modelItem.Label)
+			}
+			if strings.Join(labels, ",") != strings.Join(test.want, ",") {
+				t.Errorf("expected the items %v, got %v", test.want, labels)
+			}
+			if len(duplicates) != len(test.duplicates) {
+				t.Fatalf("expected the duplicates %v, got %v", test.duplicates, duplicates)
+			}
+			for key, owner := range test.duplicates {
+				if duplicates[key] != owner {
+					t.Errorf("expected %s to be a duplicate of %s, got %v", key, owner, duplicates)
+				}
+			}
+		})
+	}
+}
+
+func TestDeploymentSwitcherConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorsv1beta1.AddToScheme(scheme)
+
+	instance := &operatorsv1beta1.CommonWebUI{}
+	instance.Name = "example-commonwebui"
+	instance.Namespace = "cs"
+	deployment, err := getDesiredDeployment(context.Background(), fake.NewClientBuilder().WithScheme(scheme).Build(), instance, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podSpec := deployment.Spec.Template.Spec
+	found := false
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == SwitcherConfigVolumeName && volume.ConfigMap != nil && volume.ConfigMap.Name == SwitcherConfigMapName {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the %s configmap in the volumes", SwitcherConfigMapName)
+	}
+
+	container := podSpec.Containers[0]
+	mountPath := ""
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == SwitcherConfigVolumeName {
+			mountPath = mount.MountPath
+		}
+	}
+	if mountPath == "" || path.Join(mountPath, SwitcherConfigMapKey) != SwitcherConfigPath {
+		t.Errorf("expected the %s volume to be mounted at the directory of %s, got %q", SwitcherConfigVolumeName, SwitcherConfigPath, mountPath)
+	}
+	for _, envVar := range container.Env {
+		if envVar.Name == "SWITCHER_CONFIG_PATH" && envVar.Value == SwitcherConfigPath {
+			return
+		}
+	}
+	t.Errorf("expected SWITCHER_CONFIG_PATH to be %s", SwitcherConfigPath)
+}
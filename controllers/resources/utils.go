@@ -20,10 +20,12 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strconv"
 
 	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 )
 
 // Returns the labels associated with the resource being created
@@ -67,34 +69,6 @@ func GetImageID(imageRegistry, imageName, defaultImageVersion, imagePostfix, env
 	return imageID
 }
 
-// Returns the int64 representation of a resource string if properly formatted. Otherwise, returns the given default value.
-func GetResourceLimitsWithDefault(valueStr string, defaultValue int64) int64 {
-	value := defaultValue
-
-	if valueStr != "" {
-		limit, errLim := strconv.ParseInt(valueStr[0:len(valueStr)-1], 10, 64)
-		if errLim == nil {
-			value = limit
-		}
-	}
-
-	return value
-}
-
-// Returns the int64 representation of a resource string if properly formatted. Otherwise, returns the given default value.
-func GetResourceMemoryWithDefault(valueStr string, defaultValue int64) int64 {
-	value := defaultValue
-
-	if valueStr != "" {
-		memory, errLim := strconv.ParseInt(valueStr[0:len(valueStr)-2], 10, 64)
-		if errLim == nil {
-			value = memory
-		}
-	}
-
-	return value
-}
-
 // Returns the given string if is not empty. Otherwise, returns default string.
 func GetStringWithDefault(str, defaultStr string) string {
 	value := str
@@ -106,10 +80,14 @@ func GetStringWithDefault(str, defaultStr string) string {
 	return value
 }
 
-func PreserveKeyValue(key string, src, dest map[string]string) {
-	if val, ok := src[key]; ok {
-		dest[key] = val
-	}
+// IsPaused returns true when the reconcile of the managed resources is paused with the paused annotation on the CR
+func IsPaused(instance *operatorsv1beta1.CommonWebUI) bool {
+	return instance.GetAnnotations()[PausedAnnotation] == "true"
+}
+
+// IsUnmanaged returns true when the object is left to manual control with the unmanaged annotation
+func IsUnmanaged(obj metav1.Object) bool {
+	return obj.GetAnnotations()[UnmanagedAnnotation] == "true"
 }
 
 func ContainsString(strs []string, search string) bool {
@@ -0,0 +1,285 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The webhooks of a Helm install are served with a certificate that the operator issues itself, so they do not
+// depend on cert-manager. OLM provides the certificate and the CA bundles of an OLM install.
+const WebhookCertSecretName = "ibm-commonui-operator-webhook-cert"
+const WebhookServiceName = "ibm-commonui-operator-webhook"
+const WebhookConfigurationName = "ibm-commonui-operator-validating-webhook"
+const CommonWebUICRDName = "commonwebuis.operators.ibm.com"
+const WebhookCertificateDuration = 365 * 24 * time.Hour
+const WebhookCertificateRenewBefore = 30 * 24 * time.Hour
+
+// The CA key is kept in the secret so the serving certificate is renewed without a new CA bundle
+const webhookCAKeyKey = "ca.key"
+
+// WebhookCertificateManager keeps the serving certificate of the webhook server valid. The certificate is
+// stored in the secret so every operator pod serves the same one, written into the certificate directory
+// of the webhook server, and its CA is injected into the CommonWebUI CRD and the ValidatingWebhookConfiguration.
+type WebhookCertificateManager struct {
+	Client    client.Client
+	APIReader client.Reader
+	Namespace string
+	CertDir   string
+}
+
+// Start renews the certificate until the operator stops, it is run by the manager
+func (m *WebhookCertificateManager) Start(ctx context.Context) error {
+	for {
+		renewAt, err := m.EnsureCertificate(ctx)
+		wait := time.Until(renewAt)
+		if err != nil {
+			log.Error(err, "Failed to ensure the webhook serving certificate")
+			wait = time.Minute
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// NeedLeaderElection is false because every operator pod serves the webhooks
+func (m *WebhookCertificateManager) NeedLeaderElection() bool {
+	return false
+}
+
+// EnsureCertificate issues the certificate when it is missing, invalid or due for renewal, writes it for the
+// webhook server and injects its CA. It returns when the certificate has to be checked again.
+func (m *WebhookCertificateManager) EnsureCertificate(ctx context.Context) (time.Time, error) {
+	var data map[string][]byte
+	var checkAt time.Time
+	//Another operator pod may store its certificate first, the certificate is read again and that one is used
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		var err error
+		data, checkAt, err = m.ensureSecret(ctx)
+		return err
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := writeWebhookCertificate(m.CertDir, data); err != nil {
+		return time.Time{}, err
+	}
+	if err := m.injectCABundle(ctx, data["ca.crt"]); err != nil {
+		return time.Time{}, err
+	}
+	return checkAt, nil
+}
+
+func (m *WebhookCertificateManager) ensureSecret(ctx context.Context) (map[string][]byte, time.Time, error) {
+	reqLogger := log.WithValues("func", "ensureSecret", "SecretName", WebhookCertSecretName, "Namespace", m.Namespace)
+
+	secret := &corev1.Secret{}
+	err := m.APIReader.Get(ctx, types.NamespacedName{Name: WebhookCertSecretName, Namespace: m.Namespace}, secret)
+	exists := err == nil
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, time.Time{}, err
+	}
+
+	data, checkAt, changed, err := getWebhookCertificateData(secret.Data, getWebhookDNSNames(m.Namespace), time.Now())
+	if err != nil || !changed {
+		return data, checkAt, err
+	}
+
+	reqLogger.Info("Issuing the webhook serving certificate")
+	secret.Name = WebhookCertSecretName
+	secret.Namespace = m.Namespace
+	secret.Labels = MergeMap(secret.Labels, LabelsForMetadata(WebhookCertSecretName))
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = data
+	if exists {
+		err = m.Client.Update(ctx, secret)
+	} else {
+		err = m.Client.Create(ctx, secret)
+	}
+	return data, checkAt, err
+}
+
+// getWebhookCertificateData returns the secret data with a valid CA and serving certificate, whether they were
+// issued again and when the certificate has to be checked again. ca.crt starts with the current CA and keeps
+// the previous CA until it expires, the webhook server may still present a certificate of the previous CA.
+func getWebhookCertificateData(existing map[string][]byte, dnsNames []string, now time.Time) (map[string][]byte, time.Time, bool, error) {
+	data := map[string][]byte{}
+	for key, value := range existing {
+		data[key] = value
+	}
+	changed := false
+
+	previousCAs := parseCertificates(data["ca.crt"])
+	caCert, caKey, err := parseKeyPair(data["ca.crt"], data[webhookCAKeyKey])
+	if err != nil || !isCAValid(caCert, now) {
+		var caKeyPEM []byte
+		caCert, _, caKeyPEM, err = issueCertificate(
+			&x509.Certificate{
+				Subject:               pkix.Name{CommonName: WebhookServiceName + "-ca"},
+				IsCA:                  true,
+				BasicConstraintsValid: true,
+				KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+			}, SelfSignedCADuration, nil, nil)
+		if err != nil {
+			return nil, time.Time{}, false, err
+		}
+		if _, caKey, err = parseKeyPair(encodeCertificates([]*x509.Certificate{caCert}), caKeyPEM); err != nil {
+			return nil, time.Time{}, false, err
+		}
+		data[webhookCAKeyKey] = caKeyPEM
+		changed = true
+	}
+	checkAt := getRenewalTime(caCert, SelfSignedCARenewBefore)
+
+	caBundle := []*x509.Certificate{caCert}
+	for _, cert := range previousCAs {
+		if cert.Equal(caCert) || !now.Before(cert.NotAfter) {
+			continue
+		}
+		caBundle = append(caBundle, cert)
+		if cert.NotAfter.Before(checkAt) {
+			checkAt = cert.NotAfter
+		}
+	}
+	if bundle := encodeCertificates(caBundle); !bytes.Equal(bundle, data["ca.crt"]) {
+		data["ca.crt"] = bundle
+		changed = true
+	}
+
+	cert, _, err := parseKeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	if err != nil || !isCertificateValid(cert, caCert, WebhookCertificateRenewBefore, dnsNames, nil, now) {
+		cert, data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey], err = issueCertificate(
+			&x509.Certificate{
+				Subject:     pkix.Name{CommonName: dnsNames[0]},
+				DNSNames:    dnsNames,
+				KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			}, WebhookCertificateDuration, caCert, caKey)
+		if err != nil {
+			return nil, time.Time{}, false, err
+		}
+		changed = true
+	}
+	if renewAt := getRenewalTime(cert, WebhookCertificateRenewBefore); renewAt.Before(checkAt) {
+		checkAt = renewAt
+	}
+
+	return data, checkAt, changed, nil
+}
+
+// getWebhookDNSNames returns the DNS names the API server uses to call the webhook service
+func getWebhookDNSNames(namespace string) []string {
+	return []string{
+		WebhookServiceName + "." + namespace + ".svc",
+		WebhookServiceName + "." + namespace + ".svc.cluster.local",
+	}
+}
+
+// writeWebhookCertificate writes the certificate and key where the webhook server reads them, the server
+// reloads them when they change
+func writeWebhookCertificate(certDir string, data map[string][]byte) error {
+	if err := os.MkdirAll(certDir, 0700); err != nil {
+		return err
+	}
+	for _, key := range []string{corev1.TLSPrivateKeyKey, corev1.TLSCertKey} {
+		path := filepath.Join(certDir, key)
+		current, err := os.ReadFile(path)
+		if err == nil && bytes.Equal(current, data[key]) {
+			continue
+		}
+		//The file is replaced in one step so the server never reads a partial certificate
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data[key], 0600); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// injectCABundle sets the CA bundle of the conversion webhook of the CommonWebUI CRD and of the validating
+// webhooks. Objects that are not installed are skipped, an OLM install has no conversion webhook in the CRD.
+func (m *WebhookCertificateManager) injectCABundle(ctx context.Context, caBundle []byte) error {
+	reqLogger := log.WithValues("func", "injectCABundle")
+
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"})
+	err := m.APIReader.Get(ctx, types.NamespacedName{Name: CommonWebUICRDName}, crd)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(caBundle)
+	strategy, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "strategy")
+	current, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+	if err == nil && strategy == "Webhook" && current != encoded {
+		reqLogger.Info("Injecting the webhook CA into the CRD", "Name", CommonWebUICRDName)
+		if err := unstructured.SetNestedField(crd.Object, encoded, "spec", "conversion", "webhook", "clientConfig", "caBundle"); err != nil {
+			return err
+		}
+		if err := m.Client.Update(ctx, crd); err != nil {
+			return fmt.Errorf("failed to inject the webhook CA into the CRD %s: %w", CommonWebUICRDName, err)
+		}
+	}
+
+	webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	err = m.APIReader.Get(ctx, types.NamespacedName{Name: WebhookConfigurationName}, webhookConfiguration)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	changed := false
+	for i := range webhookConfiguration.Webhooks {
+		if !bytes.Equal(webhookConfiguration.Webhooks[i].ClientConfig.CABundle, caBundle) {
+			webhookConfiguration.Webhooks[i].ClientConfig.CABundle = caBundle
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	reqLogger.Info("Injecting the webhook CA into the ValidatingWebhookConfiguration", "Name", WebhookConfigurationName)
+	if err := m.Client.Update(ctx, webhookConfiguration); err != nil {
+		return fmt.Errorf("failed to inject the webhook CA into the ValidatingWebhookConfiguration %s: %w", WebhookConfigurationName, err)
+	}
+	return nil
+}
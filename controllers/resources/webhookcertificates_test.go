@@ -0,0 +1,187 @@
+//
+// Copyright 2026 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetWebhookCertificateData(t *testing.T) {
+	dnsNames := getWebhookDNSNames("cs")
+	now := time.Now()
+
+	data, checkAt, changed, err := getWebhookCertificateData(nil, dnsNames, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the certificate to be issued")
+	}
+	caCert, _, err := parseKeyPair(data["ca.crt"], data[webhookCAKeyKey])
+	if err != nil {
+		t.Fatalf("expected a CA key pair, got %v", err)
+	}
+	cert, _, err := parseKeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		t.Fatalf("expected a serving key pair, got %v", err)
+	}
+	if !isCertificateValid(cert, caCert, WebhookCertificateRenewBefore, dnsNames, nil, now) {
+		t.Errorf("expected the serving certificate to be issued by the CA for %v, got %v", dnsNames, cert.DNSNames)
+	}
+	if want := getRenewalTime(cert, WebhookCertificateRenewBefore); !checkAt.Equal(want) {
+		t.Errorf("expected a check at %s, got %s", want, checkAt)
+	}
+
+	t.Run("valid certificate", func(t *testing.T) {
+		_, _, changed, err := getWebhookCertificateData(data, dnsNames, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changed {
+			t.Error("expected the valid certificate to be kept")
+		}
+	})
+
+	t.Run("certificate due for renewal", func(t *testing.T) {
+		renewed, _, changed, err := getWebhookCertificateData(data, dnsNames, checkAt.Add(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !changed || bytes.Equal(renewed[corev1.TLSCertKey], data[corev1.TLSCertKey]) {
+			t.Error("expected the serving certificate to be issued again")
+		}
+		if !bytes.Equal(renewed["ca.crt"], data["ca.crt"]) {
+			t.Error("expected the CA bundle to be kept")
+		}
+	})
+
+	t.Run("CA due for renewal", func(t *testing.T) {
+		later := getRenewalTime(caCert, SelfSignedCARenewBefore).Add(time.Minute)
+		renewed, _, changed, err := getWebhookCertificateData(data, dnsNames, later)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundle := parseCertificates(renewed["ca.crt"])
+		if !changed || len(bundle) != 2 || bundle[0].Equal(caCert) || !bundle[1].Equal(caCert) {
+			t.Fatalf("expected a new CA followed by the previous CA, got %d certificates", len(bundle))
+		}
+		cert, _, err := parseKeyPair(renewed[corev1.TLSCertKey], renewed[corev1.TLSPrivateKeyKey])
+		if err != nil || cert.CheckSignatureFrom(bundle[0]) != nil {
+			t.Error("expected the serving certificate to be issued by the new CA")
+		}
+	})
+
+	t.Run("other service", func(t *testing.T) {
+		_, _, changed, err := getWebhookCertificateData(data, getWebhookDNSNames("other"), now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !changed {
+			t.Error("expected the certificate to be issued again for the DNS names of the service")
+		}
+	})
+}
+
+func TestWebhookCertificateManager(t *testing.T) {
+	ctx := context.Background()
+
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": CommonWebUICRDName},
+		"spec": map[string]interface{}{
+			"conversion": map[string]interface{}{
+				"strategy": "Webhook",
+				"webhook": map[string]interface{}{
+					"clientConfig": map[string]interface{}{
+						"service": map[string]interface{}{"name": WebhookServiceName, "namespace": "cs", "path": "/convert"},
+					},
+				},
+			},
+		},
+	}}
+	webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: WebhookConfigurationName},
+		Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "vcommonwebui.kb.io"}, {Name: "vcommonwebui-v1beta1.kb.io"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(crd, webhookConfiguration).Build()
+
+	m := &WebhookCertificateManager{Client: c, APIReader: c, Namespace: "cs", CertDir: filepath.Join(t.TempDir(), "serving-certs")}
+	if _, err := m.EnsureCertificate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: WebhookCertSecretName, Namespace: "cs"}, secret); err != nil {
+		t.Fatalf("expected the certificate secret, got %v", err)
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		written, err := os.ReadFile(filepath.Join(m.CertDir, key))
+		if err != nil || !bytes.Equal(written, secret.Data[key]) {
+			t.Errorf("expected %s to be written to the certificate directory", key)
+		}
+	}
+
+	if err := c.Get(ctx, types.NamespacedName{Name: CommonWebUICRDName}, crd); err != nil {
+		t.Fatal(err)
+	}
+	caBundle, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+	if caBundle != base64.StdEncoding.EncodeToString(secret.Data["ca.crt"]) {
+		t.Error("expected the CA to be injected into the conversion webhook of the CRD")
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: WebhookConfigurationName}, webhookConfiguration); err != nil {
+		t.Fatal(err)
+	}
+	for _, webhook := range webhookConfiguration.Webhooks {
+		if !bytes.Equal(webhook.ClientConfig.CABundle, secret.Data["ca.crt"]) {
+			t.Errorf("expected the CA to be injected into the webhook %s", webhook.Name)
+		}
+	}
+
+	//A second operator pod serves the certificate that is already stored
+	other := &WebhookCertificateManager{Client: c, APIReader: c, Namespace: "cs", CertDir: filepath.Join(t.TempDir(), "serving-certs")}
+	if _, err := other.EnsureCertificate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	written, err := os.ReadFile(filepath.Join(other.CertDir, corev1.TLSCertKey))
+	if err != nil || !bytes.Equal(written, secret.Data[corev1.TLSCertKey]) {
+		t.Error("expected the stored certificate to be served by every operator pod")
+	}
+}
+
+func TestWebhookCertificateManagerWithoutWebhookConfiguration(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+
+	m := &WebhookCertificateManager{Client: c, APIReader: c, Namespace: "cs", CertDir: t.TempDir()}
+	if _, err := m.EnsureCertificate(context.Background()); err != nil {
+		t.Errorf("expected the missing CRD and ValidatingWebhookConfiguration to be skipped, got %v", err)
+	}
+}
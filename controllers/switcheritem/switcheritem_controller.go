@@ -0,0 +1,193 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+	res "github.com/IBM/ibm-commonui-operator/controllers/resources"
+)
+
+var log = logf.Log.WithName("controller_switcheritem")
+
+// The switcher model spans every SwitcherItem in the watched namespaces, so all events
+// are funneled into a single request that rebuilds the complete model
+const switcherModelReconcile = "SWITCHER_MODEL_RECONCILE"
+
+// SwitcherItemReconciler reconciles a SwitcherItem object
+type SwitcherItemReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=operators.ibm.com,resources=switcheritems,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=operators.ibm.com,resources=switcheritems/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operators.ibm.com,resources=switcheritems/finalizers,verbs=update
+
+// Reconcile validates every SwitcherItem, reports the result in the item status and publishes
+// the ordered, deduplicated list of valid items to the switcher configmap of each CommonWebUI
+func (r *SwitcherItemReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling SwitcherItem Controller")
+
+	itemList := &operatorsv1alpha1.SwitcherItemList{}
+	err := r.Client.List(ctx, itemList)
+	if err != nil {
+		reqLogger.Error(err, "Failed to list SwitcherItems")
+		return ctrl.Result{}, err
+	}
+
+	originalStatuses := map[types.NamespacedName]*operatorsv1alpha1.SwitcherItemStatus{}
+	var validItems []operatorsv1alpha1.SwitcherItem
+	for i := range itemList.Items {
+		item := &itemList.Items[i]
+		originalStatuses[types.NamespacedName{Name: item.Name, Namespace: item.Namespace}] = item.Status.DeepCopy()
+
+		errs := res.ValidateCloudPakInfo(item.Spec.CloudPakInfo, field.NewPath("spec", "cloudPakInfo"))
+		if len(errs) > 0 {
+			reqLogger.Info("SwitcherItem is invalid and will not be published", "item.Name", item.Name, "item.Namespace", item.Namespace,
+				"errors", errs.ToAggregate().Error())
+			res.SetSwitcherItemCondition(item, operatorsv1alpha1.SwitcherItemConditionValid, metav1.ConditionFalse,
+				operatorsv1alpha1.ReasonInvalidCloudPakInfo, errs.ToAggregate().Error())
+			res.SetSwitcherItemCondition(item, operatorsv1alpha1.SwitcherItemConditionPublished, metav1.ConditionFalse,
+				operatorsv1alpha1.ReasonInvalidCloudPakInfo, "The item is not published because it is invalid")
+			continue
+		}
+
+		res.SetSwitcherItemCondition(item, operatorsv1alpha1.SwitcherItemConditionValid, metav1.ConditionTrue,
+			operatorsv1alpha1.ReasonValidationSucceeded, "The item is valid")
+		validItems = append(validItems, *item)
+	}
+
+	model, duplicates := res.BuildSwitcherModel(validItems)
+
+	var errs []error
+	for i := range itemList.Items {
+		item := &itemList.Items[i]
+		key := types.NamespacedName{Name: item.Name, Namespace: item.Namespace}
+
+		if owner, found := duplicates[key]; found {
+			res.SetSwitcherItemCondition(item, operatorsv1alpha1.SwitcherItemConditionPublished, metav1.ConditionFalse,
+				operatorsv1alpha1.ReasonDuplicateLabel, fmt.Sprintf("Label %q is already published by SwitcherItem %s", item.Spec.CloudPakInfo.Label, owner))
+		} else if isValid(item) {
+			res.SetSwitcherItemCondition(item, operatorsv1alpha1.SwitcherItemConditionPublished, metav1.ConditionTrue,
+				operatorsv1alpha1.ReasonPublished, "The item is published in the product switcher")
+		}
+		item.Status.ObservedGeneration = item.Generation
+
+		if !reflect.DeepEqual(originalStatuses[key], &item.Status) {
+			reqLogger.Info("Updating SwitcherItem status", "item.Name", item.Name, "item.Namespace", item.Namespace)
+			if err := r.Client.Status().Update(ctx, item); err != nil {
+				reqLogger.Error(err, "Failed to update SwitcherItem status", "item.Name", item.Name, "item.Namespace", item.Namespace)
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	// Publish the model next to every common-web-ui instance
+	instanceList := &operatorsv1beta1.CommonWebUIList{}
+	err = r.Client.List(ctx, instanceList)
+	if err != nil {
+		reqLogger.Error(err, "Failed to list CommonWebUI CRs")
+		return ctrl.Result{}, err
+	}
+	for i := range instanceList.Items {
+		if err := res.ReconcileSwitcherConfigMap(ctx, r.Client, &instanceList.Items[i], model); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return ctrl.Result{}, utilerrors.NewAggregate(errs)
+	}
+
+	reqLogger.Info("SWITCHER ITEM CONTROLLER RECONCILE ALL DONE", "publishedItems", len(model.Items))
+	return ctrl.Result{}, nil
+}
+
+func isValid(item *operatorsv1alpha1.SwitcherItem) bool {
+	for _, condition := range item.Status.Conditions {
+		if condition.Type == operatorsv1alpha1.SwitcherItemConditionValid {
+			return condition.Status == metav1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func enqueueSwitcherModel(a client.Object) []ctrl.Request {
+	return []ctrl.Request{
+		{NamespacedName: types.NamespacedName{
+			Name: switcherModelReconcile,
+		}},
+	}
+}
+
+func commonWebUIPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+	}
+}
+
+func switcherConfigMapPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectNew.GetName() == res.SwitcherConfigMapName
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return e.Object.GetName() == res.SwitcherConfigMapName
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SwitcherItemReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		//Status updates made by this controller do not change the generation and are skipped
+		For(&operatorsv1alpha1.SwitcherItem{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &operatorsv1beta1.CommonWebUI{}},
+			handler.EnqueueRequestsFromMapFunc(enqueueSwitcherModel), builder.WithPredicates(commonWebUIPredicate())).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(enqueueSwitcherModel), builder.WithPredicates(switcherConfigMapPredicate())).
+		Complete(r)
+}
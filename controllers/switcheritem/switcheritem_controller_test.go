@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
+)
+
+func TestReconcileSwitcherItemStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorsv1alpha1.AddToScheme(scheme)
+	_ = operatorsv1beta1.AddToScheme(scheme)
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	item := func(name, label string, age time.Duration) *operatorsv1alpha1.SwitcherItem {
+		return &operatorsv1alpha1.SwitcherItem{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "cs", Generation: 1, CreationTimestamp: metav1.NewTime(created.Add(-age))},
+			Spec:       operatorsv1alpha1.SwitcherItemSpec{CloudPakInfo: operatorsv1alpha1.CloudPakInfo{Label: label}},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		item("older", "cp4d", 2*time.Hour),
+		item("newer", "cp4d", time.Hour),
+		item("invalid", "", time.Hour),
+	).Build()
+	r := &SwitcherItemReconciler{Client: c, Scheme: scheme}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: switcherModelReconcile}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		valid     metav1.ConditionStatus
+		published metav1.ConditionStatus
+		reason    string
+	}{
+		{name: "older", valid: metav1.ConditionTrue, published: metav1.ConditionTrue, reason: operatorsv1alpha1.ReasonPublished},
+		{name: "newer", valid: metav1.ConditionTrue, published: metav1.ConditionFalse, reason: operatorsv1alpha1.ReasonDuplicateLabel},
+		{name: "invalid", valid: metav1.ConditionFalse, published: metav1.ConditionFalse, reason: operatorsv1alpha1.ReasonInvalidCloudPakInfo},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			item := &operatorsv1alpha1.SwitcherItem{}
+			if err := c.Get(context.Background(), types.NamespacedName{Name: test.name, Namespace: "cs"}, item); err != nil {
+				t.Fatal(err)
+			}
+			if item.Status.ObservedGeneration != item.Generation {
+				t.Errorf("expected the observed generation %d, got %d", item.Generation, item.Status.ObservedGeneration)
+			}
+			valid := meta.FindStatusCondition(item.Status.Conditions, operatorsv1alpha1.SwitcherItemConditionValid)
+			if valid == nil || valid.Status != test.valid {
+				t.Errorf("expected the Valid condition %s, got %+v", test.valid, valid)
+			}
+			published := meta.FindStatusCondition(item.Status.Conditions, operatorsv1alpha1.SwitcherItemConditionPublished)
+			if published == nil || published.Status != test.published || published.Reason != test.reason {
+				t.Errorf("expected the Published condition %s with reason %s, got %+v", test.published, test.reason, published)
+			}
+		})
+	}
+}
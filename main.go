@@ -21,8 +21,10 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -38,11 +40,13 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	runtimescheme "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -55,9 +59,11 @@ import (
 
 	"github.com/IBM/controller-filtered-cache/filteredcache"
 	operatorsv1alpha1 "github.com/IBM/ibm-commonui-operator/api/v1alpha1"
+	operatorsv1beta1 "github.com/IBM/ibm-commonui-operator/api/v1beta1"
 	im "github.com/IBM/ibm-commonui-operator/apis/operator/v1alpha1"
 	commonwebuicontrollers "github.com/IBM/ibm-commonui-operator/controllers/commonwebui"
 	res "github.com/IBM/ibm-commonui-operator/controllers/resources"
+	switcheritemcontrollers "github.com/IBM/ibm-commonui-operator/controllers/switcheritem"
 	"github.com/IBM/ibm-commonui-operator/version"
 	//+kubebuilder:scaffold:imports
 )
@@ -93,6 +99,7 @@ func init() {
 
 	// add common web ui scheme to controller
 	utilruntime.Must(operatorsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(operatorsv1beta1.AddToScheme(scheme))
 
 	//Currently was having issues with reconciling autoscaling,
 	//getting too many updates
@@ -123,6 +130,7 @@ func getFilteredCache(namespaces []string) cache.NewCacheFunc {
 		corev1.SchemeGroupVersion.WithKind("Service"): {
 			LabelSelector: commonSelector,
 		},
+		//The other secrets, like the ones provided in the CR, are read from the API server
 		corev1.SchemeGroupVersion.WithKind("Secret"): {
 			FieldSelector: "metadata.name==" + res.UICertSecretName,
 		},
@@ -132,6 +140,11 @@ func getFilteredCache(namespaces []string) cache.NewCacheFunc {
 }
 
 func main() {
+	//The render subcommand prints the managed resources for a CR file and does not start the manager
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		os.Exit(runRender(os.Args[2:]))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
@@ -211,6 +224,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := operatorsv1beta1.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
 	// Setup Scheme for cert-manager
 	if err := certmgr.AddToScheme(mgr.GetScheme()); err != nil {
 		log.Error(err, "")
@@ -237,11 +255,61 @@ func main() {
 	}
 
 	if err = (&commonwebuicontrollers.CommonWebUIReconciler{
+		Client:    res.NewMetricsClient(mgr.GetClient()),
+		APIReader: mgr.GetAPIReader(),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("ibm-commonui-operator"),
+		IsCncf:    isCncf,
+
+		CertWaitTimeout: getCertWaitTimeout(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CommonWebUI")
+		os.Exit(1)
+	}
+
+	// The webhook server needs a serving certificate, so the webhooks are only registered when the
+	// deployment provides one (see config/webhook and config/certmanager). The v1beta1 webhook also
+	// serves the conversion between the CommonWebUI versions.
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = (&operatorsv1alpha1.CommonWebUI{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CommonWebUI", "version", "v1alpha1")
+			os.Exit(1)
+		}
+		if err = (&operatorsv1beta1.CommonWebUI{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CommonWebUI", "version", "v1beta1")
+			os.Exit(1)
+		}
+
+		// Without OLM or cert-manager the operator issues the serving certificate itself and injects its CA
+		// into the CRD and the ValidatingWebhookConfiguration, see the Helm charts
+		if os.Getenv("MANAGE_WEBHOOK_CERT") == "true" {
+			webhookServer := mgr.GetWebhookServer()
+			if webhookServer.CertDir == "" {
+				webhookServer.CertDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+			}
+			certManager := &res.WebhookCertificateManager{
+				Client:    mgr.GetClient(),
+				APIReader: mgr.GetAPIReader(),
+				Namespace: os.Getenv("OPERATOR_NAMESPACE"),
+				CertDir:   webhookServer.CertDir,
+			}
+			// The webhook server only starts with a certificate in place
+			if _, err = certManager.EnsureCertificate(context.Background()); err != nil {
+				setupLog.Error(err, "unable to set up the webhook serving certificate")
+				os.Exit(1)
+			}
+			if err = mgr.Add(certManager); err != nil {
+				setupLog.Error(err, "unable to add the webhook certificate manager")
+				os.Exit(1)
+			}
+		}
+	}
+
+	if err = (&switcheritemcontrollers.SwitcherItemReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
-		IsCncf: isCncf,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "CommonWebUI")
+		setupLog.Error(err, "unable to create controller", "controller", "SwitcherItem")
 		os.Exit(1)
 	}
 	//+kubebuilder:scaffold:builder
@@ -262,6 +330,93 @@ func main() {
 	}
 }
 
+// runRender prints the resources the operator would manage for a CommonWebUI CR without contacting a cluster,
+// for example: manager render --cr commonwebui.yaml --cluster-type cncf --namespace cs
+func runRender(args []string) int {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	crFile := flags.String("cr", "", "The CommonWebUI CR to render, v1alpha1 and v1beta1 are supported.")
+	clusterType := flags.String("cluster-type", "openshift", "The kubernetes cluster type, openshift or cncf.")
+	namespace := flags.String("namespace", "", "The namespace of the CR, overrides the namespace in the CR file.")
+	clusterAddress := flags.String("cluster-address", res.DefaultRenderClusterAddress,
+		"The cluster_address of the ibmcloud-cluster-info configmap, it is the route host.")
+	caFile := flags.String("ca-cert", "", "A file with the CA certificate used as the route destination CA, a placeholder is used when not set.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *crFile == "" {
+		fmt.Fprintln(os.Stderr, "render: --cr is required")
+		flags.Usage()
+		return 2
+	}
+	if *clusterType != "openshift" && *clusterType != "cncf" {
+		fmt.Fprintf(os.Stderr, "render: unknown cluster type %q, use openshift or cncf\n", *clusterType)
+		return 2
+	}
+
+	instance, err := readCommonWebUI(*crFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		return 1
+	}
+	if *namespace != "" {
+		instance.Namespace = *namespace
+	}
+	if instance.Namespace == "" {
+		instance.Namespace = res.DefaultNamespace
+	}
+
+	caCert := []byte("<route destination CA certificate>")
+	if *caFile != "" {
+		caCert, err = os.ReadFile(*caFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "render: %v\n", err)
+			return 1
+		}
+	}
+
+	objects, err := res.RenderManifests(context.Background(), scheme, instance, res.RenderOptions{
+		IsCncf:         *clusterType == "cncf",
+		ClusterAddress: *clusterAddress,
+		CACert:         caCert,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		return 1
+	}
+
+	if err = res.WriteManifests(os.Stdout, objects); err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// readCommonWebUI reads a CommonWebUI CR from a YAML or JSON file, a v1alpha1 CR is converted to v1beta1
+func readCommonWebUI(file string) (*operatorsv1beta1.CommonWebUI, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %w", file, err)
+	}
+
+	switch cr := obj.(type) {
+	case *operatorsv1beta1.CommonWebUI:
+		return cr, nil
+	case conversion.Convertible:
+		instance := &operatorsv1beta1.CommonWebUI{}
+		if err := cr.ConvertTo(instance); err != nil {
+			return nil, fmt.Errorf("unable to convert %s to %s: %w", file, operatorsv1beta1.GroupVersion, err)
+		}
+		return instance, nil
+	}
+	return nil, fmt.Errorf("%s does not contain a CommonWebUI CR", file)
+}
+
 // Returns the Namespace the operator should be watching for changes
 func getWatchNamespace() (string, error) {
 	// WatchNamespaceEnvVar is the constant for env variable WATCH_NAMESPACE
@@ -276,6 +431,21 @@ func getWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+func getCertWaitTimeout() time.Duration {
+	// CERT_WAIT_TIMEOUT is how long the reconcile waits for the certificate secret
+	// before the CR is marked degraded, for example "10m".
+	value := os.Getenv("CERT_WAIT_TIMEOUT")
+	if value == "" {
+		return res.DefaultCertWaitTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		setupLog.Error(err, "Invalid CERT_WAIT_TIMEOUT, using the default", "value", value, "default", res.DefaultCertWaitTimeout)
+		return res.DefaultCertWaitTimeout
+	}
+	return timeout
+}
+
 func isCncf(mgr manager.Manager) (iscncf bool, err error) {
 	//We need to determine the cluster type during startup
 	//so we will use direct API calls since they are only done once